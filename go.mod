@@ -18,6 +18,7 @@ require (
 require (
 	github.com/pganalyze/pg_query_go/v6 v6.1.0
 	github.com/wasilibs/go-pgquery v0.0.0-20250409022910-10ac41983c07
+	google.golang.org/protobuf v1.36.7
 )
 
 require (
@@ -30,6 +31,5 @@ require (
 	golang.org/x/mod v0.17.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
 	golang.org/x/sys v0.21.0 // indirect
-	google.golang.org/protobuf v1.36.7 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
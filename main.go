@@ -1,11 +1,398 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/tools/go/analysis/singlechecker"
 
+	"github.com/houqp/sqlvet/pkg/cli"
+	"github.com/houqp/sqlvet/pkg/config"
+	"github.com/houqp/sqlvet/pkg/schema"
 	"github.com/houqp/sqlvet/pkg/vet"
 )
 
+type SqlVet struct {
+	QueryCnt int
+	ErrCnt   int
+
+	Cfg         config.Config
+	ProjectRoot string
+	Databases   vet.Databases
+}
+
+// NewSqlVet creates SqlVet for the provided project root
+func NewSqlVet(projectRoot string) (*SqlVet, error) {
+	cfg, err := config.Load(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs, err := vet.LoadDatabases(projectRoot, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SqlVet{
+		Cfg:         cfg,
+		ProjectRoot: projectRoot,
+		Databases:   dbs,
+	}, nil
+}
+
+// Vet performs whole-program analysis on the project root
+func (s *SqlVet) Vet(errFormat bool) error {
+	queries, err := vet.CheckDir(
+		s.Databases,
+		s.ProjectRoot,
+		s.Cfg.BuildFlags,
+		s.Cfg.Matchers(),
+	)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].Position.Filename != queries[j].Position.Filename {
+			return queries[i].Position.Filename < queries[j].Position.Filename
+		}
+		return queries[i].Position.Offset < queries[j].Position.Offset
+	})
+
+	for _, q := range queries {
+		s.QueryCnt++
+
+		if q.Err == nil {
+			cli.Debug("query detected at %s", q.Position)
+		} else {
+			s.reportError(q.Called, q.Position, q.Query, q.Err, errFormat)
+		}
+
+		for _, scan := range q.Scans {
+			if scan.Err != nil {
+				s.reportError("Scan", scan.Position, q.Query, scan.Err, errFormat)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SqlVet) reportError(called string, pos token.Position, query string, err error, errFormat bool) {
+	s.ErrCnt++
+	if errFormat {
+		relFilePath, rerr := filepath.Rel(s.ProjectRoot, pos.Filename)
+		if rerr != nil {
+			relFilePath = pos.Filename
+		}
+		// format ref: https://github.com/reviewdog/reviewdog#errorformat
+		cli.Show("%s:%d:%d: %v", relFilePath, pos.Line, pos.Column, err)
+		return
+	}
+
+	cli.Bold("%s @ %s", called, pos)
+	if query != "" {
+		cli.Show("\t%s\n", query)
+	}
+	cli.Error("\tERROR: %v", err)
+	if err == vet.ErrQueryArgUnsafe {
+		cli.Show("\tHINT: if this is a false positive, annotate with `// sqlvet: ignore` comment")
+	}
+	cli.Show("")
+}
+
+// PrintSchema dumps loaded schema tables into stdout
+func (s *SqlVet) PrintSchema() {
+	if len(s.Cfg.SchemaSources()) == 0 && len(s.Cfg.Databases) == 0 {
+		cli.Show("[!] No schema specified, will run without table and column validation.")
+		return
+	}
+
+	if sources := s.Cfg.SchemaSources(); len(sources) > 0 {
+		cli.Show("Loaded DB schema from %s", strings.Join(sources, ", "))
+		printTables(s.Databases.Default.Tables)
+	}
+	for i, db := range s.Databases.Named {
+		cli.Show("Loaded DB schema of database %s from %s",
+			db.Name, strings.Join(s.Cfg.Databases[i].SchemaSources(), ", "))
+		printTables(db.Schema.Tables)
+	}
+}
+
+func printTables(tables map[string]schema.Table) {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cli.Show("\ttable %s with %d columns", name, len(tables[name].Columns))
+	}
+}
+
+// PrintSummary dumps analysis stats into stdout
+func (s *SqlVet) PrintSummary() {
+	cli.Show("Checked %d SQL queries.", s.QueryCnt)
+	if s.ErrCnt == 0 {
+		cli.Success("🎉 Everything is awesome!")
+	} else {
+		cli.Error("Identified %d errors.", s.ErrCnt)
+	}
+}
+
+func runCheck(args []string) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	wholeProgram := flags.Bool("whole-program", false, "run call graph based analysis on a go module instead of go/analysis")
+	errFormat := flags.Bool("e", false, "print errors in errorformat, i.e. file:line:col: message")
+	flags.BoolVar(&cli.Verbose, "v", false, "verbose output")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sqlvet check [-flag] [package]\n")
+		fmt.Fprintf(os.Stderr, "       sqlvet check --whole-program [-flag] DIR\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	if !hasFlag(args, "whole-program") {
+		// hand over to the go/analysis driver, which has its own flag set
+		os.Args = append([]string{os.Args[0]}, args...)
+		singlechecker.Main(vet.Analyzer)
+		return
+	}
+
+	flags.Parse(args)
+	if !*wholeProgram || flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if cli.Verbose {
+		log.SetLevel(log.DebugLevel)
+	}
+
+	s, err := NewSqlVet(flags.Arg(0))
+	if err != nil {
+		cli.Exit(err)
+	}
+
+	s.PrintSchema()
+	if err := s.Vet(*errFormat); err != nil {
+		cli.Exit(err)
+	}
+	s.PrintSummary()
+
+	if s.ErrCnt > 0 {
+		os.Exit(1)
+	}
+}
+
+// ReportUsage prints schema tables and columns not referenced by any query
+// in the project, for each database with a schema
+func (s *SqlVet) ReportUsage() error {
+	queries, err := vet.CheckDir(
+		s.Databases,
+		s.ProjectRoot,
+		s.Cfg.BuildFlags,
+		s.Cfg.Matchers(),
+	)
+	if err != nil {
+		return err
+	}
+
+	usages := map[string]*vet.Usage{}
+	for _, q := range queries {
+		s.QueryCnt++
+		if q.Usage == nil {
+			continue
+		}
+		if usages[q.Database] == nil {
+			usages[q.Database] = vet.NewUsage()
+		}
+		usages[q.Database].Merge(q.Usage)
+	}
+
+	if len(s.Cfg.SchemaSources()) > 0 {
+		s.printUnused("default database", s.Databases.Default.Tables, usages[""])
+	}
+	for _, db := range s.Databases.Named {
+		s.printUnused("database "+db.Name, db.Schema.Tables, usages[db.Name])
+	}
+	cli.Show("Checked %d SQL queries.", s.QueryCnt)
+	return nil
+}
+
+func (s *SqlVet) printUnused(name string, tables map[string]schema.Table, usage *vet.Usage) {
+	if usage == nil {
+		usage = vet.NewUsage()
+	}
+	unusedTables, unusedCols := usage.Unused(tables)
+	if len(unusedTables) == 0 && len(unusedCols) == 0 {
+		cli.Success("All tables and columns of %s are used.", name)
+		return
+	}
+
+	cli.Bold("Unused in %s:", name)
+	for _, table := range unusedTables {
+		cli.Show("\ttable %s", table)
+	}
+	keys := make([]string, 0, len(unusedCols))
+	for key := range unusedCols {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, col := range unusedCols[key] {
+			cli.Show("\tcolumn %s.%s", key, col)
+		}
+	}
+	cli.Show("")
+}
+
+func runReport(args []string) {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	flags.BoolVar(&cli.Verbose, "v", false, "verbose output")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sqlvet report usage [-flag] DIR\n\n")
+		fmt.Fprintf(os.Stderr, "Reports schema tables and columns never referenced by queries in DIR.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "usage" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	flags.Parse(args[1:])
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if cli.Verbose {
+		log.SetLevel(log.DebugLevel)
+	}
+
+	s, err := NewSqlVet(flags.Arg(0))
+	if err != nil {
+		cli.Exit(err)
+	}
+	if len(s.Cfg.SchemaSources()) == 0 && len(s.Cfg.Databases) == 0 {
+		cli.Exit(fmt.Errorf("no schema specified, usage report requires schema_path in sqlvet.toml"))
+	}
+	if err := s.ReportUsage(); err != nil {
+		cli.Exit(err)
+	}
+}
+
+// Impact reports queries that are valid against the current schema but
+// break once migration is applied to schema of database dbName
+func (s *SqlVet) Impact(dbName, migration string, errFormat bool) error {
+	before, after, err := vet.LoadMigrated(s.ProjectRoot, s.Cfg, s.Databases, dbName, migration)
+	if err != nil {
+		return err
+	}
+
+	queries, err := vet.CheckDir(
+		before,
+		s.ProjectRoot,
+		s.Cfg.BuildFlags,
+		s.Cfg.Matchers(),
+	)
+	if err != nil {
+		return err
+	}
+	s.QueryCnt = len(queries)
+
+	broken := vet.Impact(queries, after)
+	sort.Slice(broken, func(i, j int) bool {
+		if broken[i].Position.Filename != broken[j].Position.Filename {
+			return broken[i].Position.Filename < broken[j].Position.Filename
+		}
+		return broken[i].Position.Offset < broken[j].Position.Offset
+	})
+	for _, q := range broken {
+		if q.Err != nil {
+			s.reportError(q.Called, q.Position, q.Query, q.Err, errFormat)
+		}
+		for _, scan := range q.Scans {
+			if scan.Err != nil {
+				s.reportError("Scan", scan.Position, q.Query, scan.Err, errFormat)
+			}
+		}
+	}
+	return nil
+}
+
+func runImpact(args []string) {
+	flags := flag.NewFlagSet("impact", flag.ExitOnError)
+	dbName := flags.String("db", "", "name of the database migration applies to, defaults to the one from schema_path")
+	errFormat := flags.Bool("e", false, "print errors in errorformat, i.e. file:line:col: message")
+	flags.BoolVar(&cli.Verbose, "v", false, "verbose output")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sqlvet impact [-flag] MIGRATION [DIR]\n\n")
+		fmt.Fprintf(os.Stderr, "Reports queries in DIR that break once MIGRATION is applied to the schema.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if cli.Verbose {
+		log.SetLevel(log.DebugLevel)
+	}
+	dir := "."
+	if flags.NArg() == 2 {
+		dir = flags.Arg(1)
+	}
+
+	s, err := NewSqlVet(dir)
+	if err != nil {
+		cli.Exit(err)
+	}
+	if err := s.Impact(*dbName, flags.Arg(0), *errFormat); err != nil {
+		cli.Exit(err)
+	}
+
+	cli.Show("Checked %d SQL queries.", s.QueryCnt)
+	if s.ErrCnt == 0 {
+		cli.Success("No query is affected by %s.", flags.Arg(0))
+		return
+	}
+	cli.Error("Identified %d errors caused by %s.", s.ErrCnt, flags.Arg(0))
+	os.Exit(1)
+}
+
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		arg = strings.TrimLeft(arg, "-")
+		if arg == name || strings.HasPrefix(arg, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		runCheck(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		runReport(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "impact" {
+		runImpact(os.Args[2:])
+		return
+	}
+
+	// default to go/analysis mode so sqlvet keeps working as
+	// `go vet -vettool=$(which sqlvet)`
 	singlechecker.Main(vet.Analyzer)
 }
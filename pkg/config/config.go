@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,10 +13,84 @@ import (
 
 // Sqlvet project config
 type Config struct {
-	DbEngine        string                   `toml:"db_engine"`
-	SchemaPath      string                   `toml:"schema_path"`
-	BuildFlags      string                   `toml:"build_flags"`
-	SqlFuncMatchers []matcher.SqlFuncMatcher `toml:"sqlfunc_matchers"`
+	DbEngine               string                   `toml:"db_engine"`
+	SchemaPath             string                   `toml:"schema_path"`
+	SchemaPaths            []string                 `toml:"schema_paths"`
+	SearchPath             []string                 `toml:"search_path"`
+	BuildFlags             string                   `toml:"build_flags"`
+	SqlFuncMatchers        []matcher.SqlFuncMatcher `toml:"sqlfunc_matchers"`
+	DisableDefaultMatchers bool                     `toml:"disable_default_matchers"`
+	Databases              []Database               `toml:"databases"`
+}
+
+// Database declares a named database with its own schema. Queries are bound
+// to it by package of the caller, receiver type of the query method call or
+// through sqlfunc matchers referring to it by name.
+type Database struct {
+	Name        string   `toml:"name"`
+	DbEngine    string   `toml:"db_engine"`
+	SchemaPath  string   `toml:"schema_path"`
+	SchemaPaths []string `toml:"schema_paths"`
+	SearchPath  []string `toml:"search_path"`
+	// Packages are import path patterns, e.g. example.com/app/billing/...
+	Packages []string `toml:"packages"`
+	// Receivers are types query methods are called on, e.g. *billing.DB,
+	// qualified by package name or import path
+	Receivers []string `toml:"receivers"`
+}
+
+// SchemaSources returns schema files, migrations directories and glob
+// patterns of the database
+func (d Database) SchemaSources() []string {
+	return schemaSources(d.SchemaPath, d.SchemaPaths)
+}
+
+func schemaSources(schemaPath string, schemaPaths []string) []string {
+	sources := []string{}
+	if schemaPath != "" {
+		sources = append(sources, schemaPath)
+	}
+	return append(sources, schemaPaths...)
+}
+
+// Matchers returns query function matchers to check, configured matchers are
+// appended to the built-in ones unless DisableDefaultMatchers is set.
+func (c Config) Matchers() []matcher.SqlFuncMatcher {
+	matchers := []matcher.SqlFuncMatcher{}
+	if !c.DisableDefaultMatchers {
+		matchers = append(matchers, matcher.DefaultMatchers()...)
+	}
+	return append(matchers, c.SqlFuncMatchers...)
+}
+
+// SchemaSources returns schema files, migrations directories and glob
+// patterns to load schema from, schema_path comes first if set.
+func (c Config) SchemaSources() []string {
+	return schemaSources(c.SchemaPath, c.SchemaPaths)
+}
+
+// validate checks databases are uniquely named, use a supported engine and
+// are declared before matchers refer to them
+func (c Config) validate() error {
+	names := map[string]bool{}
+	for _, db := range c.Databases {
+		if db.Name == "" {
+			return fmt.Errorf("database name is required")
+		}
+		if names[db.Name] {
+			return fmt.Errorf("database `%s` is declared more than once", db.Name)
+		}
+		names[db.Name] = true
+		if db.DbEngine != "" && db.DbEngine != "postgres" {
+			return fmt.Errorf("database `%s` uses unsupported db_engine %s", db.Name, db.DbEngine)
+		}
+	}
+	for _, m := range c.SqlFuncMatchers {
+		if m.Database != "" && !names[m.Database] {
+			return fmt.Errorf("sqlfunc matcher for %s refers to unknown database `%s`", m.PkgPath, m.Database)
+		}
+	}
+	return nil
 }
 
 // Load sqlvet config from project root
@@ -34,5 +109,9 @@ func Load(searchPath string) (conf Config, err error) {
 	}
 
 	err = toml.Unmarshal(data, &conf)
+	if err != nil {
+		return
+	}
+	err = conf.validate()
 	return
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/houqp/sqlvet/pkg/config"
+	"github.com/houqp/sqlvet/pkg/matcher"
 )
 
 type ConfigTmpDir struct{}
@@ -65,6 +66,124 @@ func (s *ConfigTests) SubTestMultipleMatchers(t *testing.T, fixtures struct {
 	assert.Equal(t, 1, len(cfg.SqlFuncMatchers[1].Rules))
 }
 
+func (s *ConfigTests) SubTestDisableDefaultMatchers(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"ConfigTmpDir"`
+}) {
+	configPath := filepath.Join(fixtures.TmpDir, "sqlvet.toml")
+	err := ioutil.WriteFile(configPath, []byte(`
+disable_default_matchers = true
+
+[[sqlfunc_matchers]]
+  pkg_path = "github.com/houqp/sqlvettest/repo"
+  [[sqlfunc_matchers.rules]]
+    func_name = "Exec"
+    query_arg_pos = 1
+`), 0644)
+	assert.NoError(t, err)
+
+	cfg, err := config.Load(fixtures.TmpDir)
+	assert.NoError(t, err)
+
+	matchers := cfg.Matchers()
+	assert.Equal(t, 1, len(matchers))
+	assert.Equal(t, "github.com/houqp/sqlvettest/repo", matchers[0].PkgPath)
+	assert.Equal(t, "Exec", matchers[0].Rules[0].FuncName)
+	assert.Equal(t, 1, matchers[0].Rules[0].QueryArgPos)
+}
+
+func (s *ConfigTests) SubTestDefaultMatchers(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"ConfigTmpDir"`
+}) {
+	configPath := filepath.Join(fixtures.TmpDir, "sqlvet.toml")
+	err := ioutil.WriteFile(configPath, []byte(`
+[[sqlfunc_matchers]]
+  pkg_path = "github.com/houqp/sqlvettest/repo"
+  [[sqlfunc_matchers.rules]]
+    query_arg_name = "query"
+`), 0644)
+	assert.NoError(t, err)
+
+	cfg, err := config.Load(fixtures.TmpDir)
+	assert.NoError(t, err)
+
+	defaults := matcher.DefaultMatchers()
+	matchers := cfg.Matchers()
+	assert.Equal(t, len(defaults)+1, len(matchers))
+	assert.Equal(t, defaults, matchers[:len(defaults)])
+	assert.Equal(t, "github.com/houqp/sqlvettest/repo", matchers[len(defaults)].PkgPath)
+}
+
+func (s *ConfigTests) SubTestSchemaPaths(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"ConfigTmpDir"`
+}) {
+	configPath := filepath.Join(fixtures.TmpDir, "sqlvet.toml")
+	err := ioutil.WriteFile(configPath, []byte(`
+schema_path = "schema.sql"
+schema_paths = ["db/schema/*.sql", "db/migrations"]
+`), 0644)
+	assert.NoError(t, err)
+
+	cfg, err := config.Load(fixtures.TmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"schema.sql", "db/schema/*.sql", "db/migrations"}, cfg.SchemaSources())
+	assert.Equal(t, []string{}, config.Config{}.SchemaSources())
+}
+
+func (s *ConfigTests) SubTestDatabases(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"ConfigTmpDir"`
+}) {
+	configPath := filepath.Join(fixtures.TmpDir, "sqlvet.toml")
+	err := ioutil.WriteFile(configPath, []byte(`
+schema_path = "schema.sql"
+
+[[databases]]
+  name = "billing"
+  schema_paths = ["db/billing/*.sql"]
+  search_path = ["billing", "public"]
+  packages = ["example.com/app/billing/..."]
+  receivers = ["*billing.DB"]
+
+[[sqlfunc_matchers]]
+  pkg_path = "example.com/app/billing/store"
+  database = "billing"
+  [[sqlfunc_matchers.rules]]
+    query_arg_name = "query"
+`), 0644)
+	assert.NoError(t, err)
+
+	cfg, err := config.Load(fixtures.TmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.Database{{
+		Name:        "billing",
+		SchemaPaths: []string{"db/billing/*.sql"},
+		SearchPath:  []string{"billing", "public"},
+		Packages:    []string{"example.com/app/billing/..."},
+		Receivers:   []string{"*billing.DB"},
+	}}, cfg.Databases)
+	assert.Equal(t, "billing", cfg.SqlFuncMatchers[0].Database)
+
+	err = ioutil.WriteFile(configPath, []byte(`
+[[databases]]
+  name = "billing"
+
+[[sqlfunc_matchers]]
+  pkg_path = "example.com/app/store"
+  database = "reports"
+`), 0644)
+	assert.NoError(t, err)
+	_, err = config.Load(fixtures.TmpDir)
+	assert.EqualError(t, err, "sqlfunc matcher for example.com/app/store refers to unknown database `reports`")
+
+	err = ioutil.WriteFile(configPath, []byte(`
+[[databases]]
+  name = "billing"
+  db_engine = "mysql"
+`), 0644)
+	assert.NoError(t, err)
+	_, err = config.Load(fixtures.TmpDir)
+	assert.EqualError(t, err, "database `billing` uses unsupported db_engine mysql")
+}
+
 // should return default config if config file is not found
 func (s *ConfigTests) SubTestNoConfigFile(t *testing.T, fixtures struct {
 	TmpDir string `fixture:"ConfigTmpDir"`
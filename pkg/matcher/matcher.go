@@ -20,6 +20,12 @@ type SqlFuncMatchRule struct {
 type SqlFuncMatcher struct {
 	PkgPath string             `toml:"pkg_path"`
 	Rules   []SqlFuncMatchRule `toml:"rules"`
+	// ExcludeFuncNames lists functions never treated as query functions,
+	// e.g. helpers rewriting a query without running it
+	ExcludeFuncNames []string `toml:"exclude_func_names"`
+	// Database is name of the database queries passed to matched functions
+	// are validated against, see config.Database
+	Database string `toml:"database"`
 
 	pkg *packages.Package
 }
@@ -60,29 +66,114 @@ func (s *SqlFuncMatcher) IterPackageExportedFuncs(cb func(*types.Func)) {
 type MatchedSqlFunc struct {
 	SSA         *ssa.Function
 	QueryArgPos int
+	Database    string
+}
+
+// MatchFunc checks fobj against matcher rules and returns position of the
+// query argument if matched. Position is zero indexed and does not take
+// method receiver into account.
+func (s *SqlFuncMatcher) MatchFunc(fobj *types.Func) (int, bool) {
+	if fobj.Pkg() == nil || fobj.Pkg().Path() != s.PkgPath {
+		return 0, false
+	}
+	sig, ok := fobj.Type().(*types.Signature)
+	if !ok {
+		return 0, false
+	}
+	for _, name := range s.ExcludeFuncNames {
+		if fobj.Name() == name {
+			return 0, false
+		}
+	}
+	for _, rule := range s.Rules {
+		if rule.FuncName != "" && fobj.Name() == rule.FuncName {
+			// callable matched one rule, no need to go through the rest
+			return rule.QueryArgPos, true
+		}
+		if rule.QueryArgName != "" {
+			sigParams := sig.Params()
+			if sigParams.Len()-1 < rule.QueryArgPos {
+				continue
+			}
+			param := sigParams.At(rule.QueryArgPos)
+			if param.Name() != rule.QueryArgName {
+				continue
+			}
+			return rule.QueryArgPos, true
+		}
+	}
+	return 0, false
 }
 
 func (s *SqlFuncMatcher) MatchSqlFuncs(prog *ssa.Program) []MatchedSqlFunc {
 	sqlfuncs := []MatchedSqlFunc{}
 	s.IterPackageExportedFuncs(func(fobj *types.Func) {
-		for _, rule := range s.Rules {
-			if rule.FuncName != "" && fobj.Name() == rule.FuncName {
-				sqlfuncs = append(sqlfuncs, MatchedSqlFunc{SSA: prog.FuncValue(fobj), QueryArgPos: rule.QueryArgPos})
-				break
-			}
-			if rule.QueryArgName != "" {
-				sigParams := fobj.Type().(*types.Signature).Params()
-				if sigParams.Len()-1 < rule.QueryArgPos {
-					continue
-				}
-				param := sigParams.At(rule.QueryArgPos)
-				if param.Name() != rule.QueryArgName {
-					continue
-				}
-				sqlfuncs = append(sqlfuncs, MatchedSqlFunc{SSA: prog.FuncValue(fobj), QueryArgPos: rule.QueryArgPos})
-				break
-			}
+		if pos, ok := s.MatchFunc(fobj); ok {
+			sqlfuncs = append(sqlfuncs, MatchedSqlFunc{SSA: prog.FuncValue(fobj), QueryArgPos: pos, Database: s.Database})
 		}
 	})
 	return sqlfuncs
 }
+
+// DefaultMatchers returns matchers for query functions from commonly used SQL
+// libraries, these are checked unless disabled in config.
+func DefaultMatchers() []SqlFuncMatcher {
+	return []SqlFuncMatcher{
+		{
+			PkgPath: "github.com/jmoiron/sqlx",
+			Rules: []SqlFuncMatchRule{
+				{QueryArgName: "query"},
+				{QueryArgName: "sql"},
+				// for methods with Context suffix
+				{QueryArgName: "query", QueryArgPos: 1},
+				{QueryArgName: "sql", QueryArgPos: 1},
+				{QueryArgName: "query", QueryArgPos: 2},
+				{QueryArgName: "sql", QueryArgPos: 2},
+			},
+			// bind type helpers take queries with `?` or named placeholders
+			// and only rewrite them
+			ExcludeFuncNames: []string{"In", "Rebind", "BindNamed"},
+		},
+		{
+			PkgPath: "database/sql",
+			Rules: []SqlFuncMatchRule{
+				{QueryArgName: "query"},
+				{QueryArgName: "sql"},
+				// for methods with Context suffix
+				{QueryArgName: "query", QueryArgPos: 1},
+				{QueryArgName: "sql", QueryArgPos: 1},
+			},
+		},
+		{
+			PkgPath: "github.com/jinzhu/gorm",
+			Rules: []SqlFuncMatchRule{
+				{QueryArgName: "sql"},
+			},
+		},
+		// TODO: xorm uses vararg, which is not supported yet
+		// {
+		// 	PkgPath: "xorm.io/xorm",
+		// 	Rules: []SqlFuncMatchRule{
+		// 		{FuncName: "SQL"},
+		// 		{FuncName: "Sql"},
+		// 		{FuncName: "Exec"},
+		// 		{FuncName: "Query"},
+		// 		{FuncName: "QueryInterface"},
+		// 		{FuncName: "QueryString"},
+		// 		{FuncName: "QuerySliceString"},
+		// 	},
+		// },
+		{
+			PkgPath: "go-gorp/gorp",
+			Rules: []SqlFuncMatchRule{
+				{QueryArgName: "query"},
+			},
+		},
+		{
+			PkgPath: "gopkg.in/gorp.v1",
+			Rules: []SqlFuncMatchRule{
+				{QueryArgName: "query"},
+			},
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package parseutil
+
+import (
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	pg_wasm "github.com/wasilibs/go-pgquery"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Parse parses SQL into the pg_query AST. The parse tree is read from JSON
+// output of the parser, which is the same tree encoded in protobuf JSON
+// mapping. Protobuf output of the wasm parser takes about twice as long to
+// produce and outweighs the cost of decoding JSON.
+func Parse(sql string) (*pg_query.ParseResult, error) {
+	tree, err := pg_wasm.ParseToJSON(sql)
+	if err != nil {
+		return nil, err
+	}
+	result := &pg_query.ParseResult{}
+	if err := protojson.Unmarshal([]byte(tree), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
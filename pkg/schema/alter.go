@@ -0,0 +1,165 @@
+package schema
+
+import (
+	"fmt"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+// Schema changes are replayed in the order they appear in DDL script.
+// Relations sqlvet doesn't track, e.g. sequences or tables created by
+// extensions, are skipped instead of reported.
+
+func lookupColumn(table Table, name string, missingOk bool) (Column, bool, error) {
+	col, ok := table.Columns[name]
+	if !ok && !missingOk {
+		return col, false, fmt.Errorf("column `%s` is not defined in table `%s`", name, table.Name)
+	}
+	return col, ok, nil
+}
+
+// applyAlterTable applies ALTER TABLE ADD/DROP COLUMN, ALTER COLUMN TYPE,
+// SET/DROP NOT NULL, SET/DROP DEFAULT, ADD/DROP IDENTITY and ADD PRIMARY
+// KEY.
+func (l *pgSchemaLoader) applyAlterTable(stmt *pg_query.AlterTableStmt) error {
+	key, ok := l.lookup(stmt.GetRelation())
+	if !ok {
+		return nil
+	}
+	table := l.tables[key]
+
+	for _, node := range stmt.GetCmds() {
+		cmd := node.GetAlterTableCmd()
+		if cmd == nil {
+			continue
+		}
+		switch cmd.GetSubtype() {
+		case pg_query.AlterTableType_AT_AddColumn:
+			col := l.parseColumnDef(cmd.GetDef().GetColumnDef())
+			if _, exists := table.Columns[col.Name]; exists && cmd.GetMissingOk() {
+				// ADD COLUMN IF NOT EXISTS
+				continue
+			}
+			table.addColumn(col)
+		case pg_query.AlterTableType_AT_DropColumn:
+			_, ok, err := lookupColumn(table, cmd.GetName(), cmd.GetMissingOk())
+			if err != nil {
+				return err
+			}
+			if ok {
+				delete(table.Columns, cmd.GetName())
+			}
+		case pg_query.AlterTableType_AT_AlterColumnType,
+			pg_query.AlterTableType_AT_SetNotNull,
+			pg_query.AlterTableType_AT_DropNotNull,
+			pg_query.AlterTableType_AT_ColumnDefault,
+			pg_query.AlterTableType_AT_AddIdentity,
+			pg_query.AlterTableType_AT_DropIdentity:
+			col, ok, err := lookupColumn(table, cmd.GetName(), cmd.GetMissingOk())
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			l.alterColumn(&col, cmd)
+			table.Columns[col.Name] = col
+		case pg_query.AlterTableType_AT_AddConstraint:
+			applyTableConstraint(table, cmd.GetDef().GetConstraint())
+		}
+	}
+	return nil
+}
+
+func (l *pgSchemaLoader) alterColumn(col *Column, cmd *pg_query.AlterTableCmd) {
+	switch cmd.GetSubtype() {
+	case pg_query.AlterTableType_AT_AlterColumnType:
+		col.Type = typeNameString(cmd.GetDef().GetColumnDef().GetTypeName())
+		col.Enum = l.columnEnum(col.Type)
+	case pg_query.AlterTableType_AT_SetNotNull:
+		col.NotNull = true
+	case pg_query.AlterTableType_AT_DropNotNull:
+		col.NotNull = false
+	case pg_query.AlterTableType_AT_ColumnDefault:
+		// DROP DEFAULT comes without expression
+		col.HasDefault = cmd.GetDef() != nil
+	case pg_query.AlterTableType_AT_AddIdentity:
+		applyColumnConstraints(col, []*pg_query.Node{cmd.GetDef()})
+	case pg_query.AlterTableType_AT_DropIdentity:
+		col.HasDefault = false
+		col.Identity = false
+		col.GeneratedAlways = false
+	}
+}
+
+// applyRename applies ALTER TABLE/VIEW RENAME TO and RENAME COLUMN
+func (l *pgSchemaLoader) applyRename(stmt *pg_query.RenameStmt) error {
+	key, ok := l.lookup(stmt.GetRelation())
+	if !ok {
+		return nil
+	}
+	table := l.tables[key]
+
+	switch stmt.GetRenameType() {
+	case pg_query.ObjectType_OBJECT_TABLE, pg_query.ObjectType_OBJECT_VIEW, pg_query.ObjectType_OBJECT_MATVIEW:
+		delete(l.tables, key)
+		table.Name = stmt.GetNewname()
+		l.tables[table.Key()] = table
+	case pg_query.ObjectType_OBJECT_COLUMN:
+		col, ok, err := lookupColumn(table, stmt.GetSubname(), stmt.GetMissingOk())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		delete(table.Columns, col.Name)
+		col.Name = stmt.GetNewname()
+		table.Columns[col.Name] = col
+	}
+	return nil
+}
+
+// applyDrop applies DROP TABLE, DROP VIEW, DROP TYPE and DROP SCHEMA
+func (l *pgSchemaLoader) applyDrop(stmt *pg_query.DropStmt) {
+	switch stmt.GetRemoveType() {
+	case pg_query.ObjectType_OBJECT_TYPE:
+		l.applyDropType(stmt)
+	case pg_query.ObjectType_OBJECT_TABLE, pg_query.ObjectType_OBJECT_VIEW, pg_query.ObjectType_OBJECT_MATVIEW:
+		for _, obj := range stmt.GetObjects() {
+			// possibly qualified name, e.g. public.users
+			names := []string{}
+			for _, item := range obj.GetList().GetItems() {
+				names = append(names, item.GetString_().GetSval())
+			}
+			rv := &pg_query.RangeVar{}
+			switch len(names) {
+			case 0:
+				continue
+			case 1:
+				rv.Relname = names[0]
+			default:
+				rv.Schemaname = names[len(names)-2]
+				rv.Relname = names[len(names)-1]
+			}
+			if key, ok := l.lookup(rv); ok {
+				delete(l.tables, key)
+			}
+		}
+	case pg_query.ObjectType_OBJECT_SCHEMA:
+		// non-empty schemas can only be dropped with CASCADE
+		for _, obj := range stmt.GetObjects() {
+			schemaName := obj.GetString_().GetSval()
+			for key, table := range l.tables {
+				if table.Schema == schemaName {
+					delete(l.tables, key)
+				}
+			}
+			for key, enum := range l.enums {
+				if enum.Schema == schemaName {
+					delete(l.enums, key)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+// splitQualifiedName splits name list of a type, e.g. auth.mood, into schema
+// and name. Schema is empty for unqualified names.
+func splitQualifiedName(names []*pg_query.Node) (string, string) {
+	parts := []string{}
+	for _, n := range names {
+		parts = append(parts, n.GetString_().GetSval())
+	}
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", parts[0]
+	default:
+		return parts[len(parts)-2], parts[len(parts)-1]
+	}
+}
+
+// lookupEnum finds enum type by name, unqualified names are resolved through
+// search path
+func (l *pgSchemaLoader) lookupEnum(schemaName string, name string) *Enum {
+	if schemaName != "" {
+		return l.enums[TableKey(schemaName, name)]
+	}
+	for _, s := range l.searchPath {
+		if e, ok := l.enums[TableKey(s, name)]; ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// columnEnum returns enum type of a column type string, e.g. mood or
+// auth.mood. Arrays of enums are not tracked.
+func (l *pgSchemaLoader) columnEnum(typ string) *Enum {
+	if strings.HasSuffix(typ, "[]") {
+		return nil
+	}
+	if schemaName, name, ok := strings.Cut(typ, "."); ok {
+		return l.lookupEnum(schemaName, name)
+	}
+	return l.lookupEnum("", typ)
+}
+
+// applyCreateEnum applies CREATE TYPE ... AS ENUM, schemaName is set for
+// statements nested in CREATE SCHEMA.
+func (l *pgSchemaLoader) applyCreateEnum(stmt *pg_query.CreateEnumStmt, schemaName string) {
+	typeSchema, name := splitQualifiedName(stmt.GetTypeName())
+	if typeSchema == "" {
+		typeSchema = schemaName
+	}
+	typeSchema = l.creationSchema(typeSchema)
+
+	enum := &Enum{Name: name, Schema: typeSchema, Values: []string{}}
+	for _, val := range stmt.GetVals() {
+		enum.Values = append(enum.Values, val.GetString_().GetSval())
+	}
+	l.enums[TableKey(enum.Schema, enum.Name)] = enum
+}
+
+// applyAlterEnum applies ALTER TYPE ... ADD VALUE and RENAME VALUE. Enum is
+// updated in place so columns already using it see the change.
+func (l *pgSchemaLoader) applyAlterEnum(stmt *pg_query.AlterEnumStmt) {
+	enum := l.lookupEnum(splitQualifiedName(stmt.GetTypeName()))
+	if enum == nil {
+		return
+	}
+
+	if stmt.GetOldVal() != "" {
+		for i, v := range enum.Values {
+			if v == stmt.GetOldVal() {
+				enum.Values[i] = stmt.GetNewVal()
+			}
+		}
+		return
+	}
+
+	if enum.HasValue(stmt.GetNewVal()) {
+		// ADD VALUE IF NOT EXISTS
+		return
+	}
+	pos := len(enum.Values)
+	for i, v := range enum.Values {
+		if v != stmt.GetNewValNeighbor() {
+			continue
+		}
+		pos = i
+		if stmt.GetNewValIsAfter() {
+			pos++
+		}
+	}
+	enum.Values = append(enum.Values[:pos], append([]string{stmt.GetNewVal()}, enum.Values[pos:]...)...)
+}
+
+// applyDropType applies DROP TYPE
+func (l *pgSchemaLoader) applyDropType(stmt *pg_query.DropStmt) {
+	for _, obj := range stmt.GetObjects() {
+		if enum := l.lookupEnum(splitQualifiedName(obj.GetTypeName().GetNames())); enum != nil {
+			delete(l.enums, TableKey(enum.Schema, enum.Name))
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package schema
+
+import (
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+// Function represents a function or aggregate signature
+type Function struct {
+	Name   string
+	Schema string
+	// MinArgs and MaxArgs bound number of arguments the function accepts,
+	// MaxArgs is -1 for VARIADIC functions
+	MinArgs int
+	MaxArgs int
+}
+
+// Accepts reports whether function can be called with n arguments
+func (f Function) Accepts(n int) bool {
+	return n >= f.MinArgs && (f.MaxArgs < 0 || n <= f.MaxArgs)
+}
+
+//go:generate go run gen_pgcatalog.go
+
+// builtinFunctionArgs lists argument count ranges of common functions and
+// aggregates from pg_catalog, overloads are merged into a single range.
+// Argument count of other functions in pgCatalogFunctions is not checked.
+var builtinFunctionArgs = map[string][2]int{
+	// aggregates
+	"count": {0, 1}, "sum": {1, 1}, "avg": {1, 1}, "min": {1, 1}, "max": {1, 1},
+	"array_agg": {1, 1}, "string_agg": {2, 2}, "bool_and": {1, 1}, "bool_or": {1, 1},
+	"every": {1, 1}, "bit_and": {1, 1}, "bit_or": {1, 1}, "xmlagg": {1, 1},
+	"json_agg": {1, 1}, "jsonb_agg": {1, 1}, "json_object_agg": {2, 2}, "jsonb_object_agg": {2, 2},
+	"stddev": {1, 1}, "stddev_pop": {1, 1}, "stddev_samp": {1, 1},
+	"variance": {1, 1}, "var_pop": {1, 1}, "var_samp": {1, 1},
+	"corr": {2, 2}, "covar_pop": {2, 2}, "covar_samp": {2, 2},
+	"percentile_cont": {1, 1}, "percentile_disc": {1, 1}, "mode": {0, 0},
+
+	// window functions, rank functions double as hypothetical-set aggregates
+	"row_number": {0, 0}, "rank": {0, -1}, "dense_rank": {0, -1},
+	"percent_rank": {0, -1}, "cume_dist": {0, -1}, "ntile": {1, 1},
+	"lag": {1, 3}, "lead": {1, 3}, "first_value": {1, 1}, "last_value": {1, 1},
+	"nth_value": {2, 2},
+
+	// math
+	"abs": {1, 1}, "ceil": {1, 1}, "ceiling": {1, 1}, "floor": {1, 1},
+	"round": {1, 2}, "trunc": {1, 2}, "sign": {1, 1}, "sqrt": {1, 1}, "cbrt": {1, 1},
+	"exp": {1, 1}, "ln": {1, 1}, "log": {1, 2}, "log10": {1, 1},
+	"power": {2, 2}, "pow": {2, 2}, "mod": {2, 2}, "div": {2, 2},
+	"gcd": {2, 2}, "lcm": {2, 2}, "pi": {0, 0}, "random": {0, 2}, "setseed": {1, 1},
+	"degrees": {1, 1}, "radians": {1, 1}, "sin": {1, 1}, "cos": {1, 1}, "tan": {1, 1},
+	"atan2": {2, 2}, "width_bucket": {2, 4},
+
+	// strings
+	"length": {1, 2}, "char_length": {1, 1}, "character_length": {1, 1},
+	"octet_length": {1, 1}, "bit_length": {1, 1},
+	"lower": {1, 1}, "upper": {1, 1}, "initcap": {1, 1},
+	"concat": {1, -1}, "concat_ws": {2, -1}, "format": {1, -1},
+	"substr": {2, 3}, "substring": {2, 3}, "left": {2, 2}, "right": {2, 2},
+	"lpad": {2, 3}, "rpad": {2, 3}, "ltrim": {1, 2}, "rtrim": {1, 2}, "btrim": {1, 2},
+	"replace": {3, 3}, "reverse": {1, 1}, "repeat": {2, 2}, "translate": {3, 3},
+	"split_part": {3, 3}, "strpos": {2, 2}, "starts_with": {2, 2},
+	"ascii": {1, 1}, "chr": {1, 1}, "to_hex": {1, 1}, "md5": {1, 1}, "sha256": {1, 1},
+	"encode": {2, 2}, "decode": {2, 2}, "convert_from": {2, 2}, "convert_to": {2, 2},
+	"quote_ident": {1, 1}, "quote_literal": {1, 1}, "quote_nullable": {1, 1},
+	"regexp_replace": {3, 6}, "regexp_match": {2, 3}, "regexp_matches": {2, 3},
+	"regexp_split_to_array": {2, 3}, "regexp_split_to_table": {2, 3},
+	"regexp_like": {2, 3}, "regexp_count": {2, 4}, "regexp_substr": {2, 6}, "regexp_instr": {2, 7},
+	"string_to_array": {2, 3}, "array_to_string": {2, 3},
+	"to_char": {2, 2}, "to_number": {2, 2}, "to_date": {2, 2}, "to_timestamp": {1, 2},
+
+	// full text search
+	"to_tsvector": {1, 2}, "to_tsquery": {1, 2}, "plainto_tsquery": {1, 2},
+	"phraseto_tsquery": {1, 2}, "websearch_to_tsquery": {1, 2},
+	"setweight": {2, 3}, "ts_rank": {2, 4}, "ts_rank_cd": {2, 4}, "ts_headline": {2, 4},
+	"strip": {1, 1}, "numnode": {1, 1}, "tsvector_to_array": {1, 1},
+
+	// date and time
+	"now": {0, 0}, "clock_timestamp": {0, 0}, "statement_timestamp": {0, 0},
+	"transaction_timestamp": {0, 0}, "timeofday": {0, 0},
+	"date_trunc": {2, 3}, "date_part": {2, 2}, "date_bin": {3, 3}, "age": {1, 2},
+	"make_date": {3, 3}, "make_time": {3, 3}, "make_timestamp": {6, 6},
+	"make_timestamptz": {6, 7}, "make_interval": {0, 7}, "isfinite": {1, 1},
+	"justify_days": {1, 1}, "justify_hours": {1, 1}, "justify_interval": {1, 1},
+	"timezone": {2, 2},
+
+	// json
+	"to_json": {1, 1}, "to_jsonb": {1, 1}, "row_to_json": {1, 2}, "array_to_json": {1, 2},
+	"json_build_object": {0, -1}, "jsonb_build_object": {0, -1},
+	"json_build_array": {0, -1}, "jsonb_build_array": {0, -1},
+	"json_object": {1, 2}, "jsonb_object": {1, 2},
+	"json_array_length": {1, 1}, "jsonb_array_length": {1, 1},
+	"json_extract_path": {1, -1}, "jsonb_extract_path": {1, -1},
+	"json_extract_path_text": {1, -1}, "jsonb_extract_path_text": {1, -1},
+	"jsonb_set": {3, 4}, "jsonb_set_lax": {3, 5}, "jsonb_insert": {3, 4},
+	"jsonb_strip_nulls": {1, 1}, "json_strip_nulls": {1, 1}, "jsonb_pretty": {1, 1},
+	"json_typeof": {1, 1}, "jsonb_typeof": {1, 1},
+	"json_each": {1, 1}, "jsonb_each": {1, 1}, "json_each_text": {1, 1}, "jsonb_each_text": {1, 1},
+	"json_array_elements": {1, 1}, "jsonb_array_elements": {1, 1},
+	"json_array_elements_text": {1, 1}, "jsonb_array_elements_text": {1, 1},
+	"json_object_keys": {1, 1}, "jsonb_object_keys": {1, 1},
+	"json_populate_record": {2, 2}, "jsonb_populate_record": {2, 2},
+	"json_to_record": {1, 1}, "jsonb_to_record": {1, 1},
+	"jsonb_path_query": {2, 4}, "jsonb_path_query_array": {2, 4}, "jsonb_path_query_first": {2, 4},
+	"jsonb_path_exists": {2, 4}, "jsonb_path_match": {2, 4},
+
+	// arrays and ranges
+	"array_length": {2, 2}, "array_upper": {2, 2}, "array_lower": {2, 2},
+	"array_ndims": {1, 1}, "array_dims": {1, 1}, "cardinality": {1, 1},
+	"array_append": {2, 2}, "array_prepend": {2, 2}, "array_cat": {2, 2},
+	"array_remove": {2, 2}, "array_replace": {3, 3}, "array_fill": {2, 3},
+	"array_position": {2, 3}, "array_positions": {2, 2},
+	"unnest": {1, -1}, "generate_series": {2, 4}, "generate_subscripts": {2, 3},
+	"int4range": {2, 3}, "int8range": {2, 3}, "numrange": {2, 3},
+	"tsrange": {2, 3}, "tstzrange": {2, 3}, "daterange": {2, 3},
+	"isempty": {1, 1}, "range_merge": {2, 2},
+
+	// system
+	"nextval": {1, 1}, "currval": {1, 1}, "setval": {2, 3}, "lastval": {0, 0},
+	"current_setting": {1, 2}, "set_config": {3, 3}, "txid_current": {0, 0},
+	"pg_sleep": {1, 1}, "pg_typeof": {1, 1}, "to_regclass": {1, 1},
+	"version": {0, 0}, "current_database": {0, 0}, "gen_random_uuid": {0, 0},
+	"num_nulls": {1, -1}, "num_nonnulls": {1, -1},
+	"pg_advisory_lock": {1, 2}, "pg_advisory_unlock": {1, 2},
+	"pg_try_advisory_lock": {1, 2}, "pg_advisory_xact_lock": {1, 2},
+	"pg_try_advisory_xact_lock": {1, 2},
+
+	// functions of commonly used extensions: uuid-ossp, pgcrypto, pg_trgm
+	// and unaccent
+	"uuid_generate_v1": {0, 0}, "uuid_generate_v4": {0, 0},
+	"crypt": {2, 2}, "gen_salt": {1, 2}, "digest": {2, 2}, "hmac": {3, 3},
+	"similarity": {2, 2}, "word_similarity": {2, 2}, "unaccent": {1, 2},
+}
+
+// LookupFunction finds overloads of a function. Functions from pg_catalog
+// are always visible, other unqualified names are resolved through schemas
+// in searchPath.
+func LookupFunction(functions map[string][]Function, searchPath []string, schemaName string, name string) ([]Function, bool) {
+	found := []Function{}
+	if schemaName == "" || schemaName == "pg_catalog" {
+		if args, ok := builtinFunctionArgs[name]; ok {
+			found = append(found, Function{Name: name, Schema: "pg_catalog", MinArgs: args[0], MaxArgs: args[1]})
+		} else if pgCatalogFunctions[name] {
+			found = append(found, Function{Name: name, Schema: "pg_catalog", MinArgs: 0, MaxArgs: -1})
+		}
+	}
+	if schemaName != "" {
+		found = append(found, functions[TableKey(schemaName, name)]...)
+		return found, len(found) > 0
+	}
+
+	if len(searchPath) == 0 {
+		searchPath = DefaultSearchPath
+	}
+	for _, s := range searchPath {
+		found = append(found, functions[TableKey(s, name)]...)
+	}
+	return found, len(found) > 0
+}
+
+// addFunction records a function overload, CREATE OR REPLACE with the same
+// argument counts doesn't add a new one.
+func (l *pgSchemaLoader) addFunction(f Function) {
+	key := TableKey(f.Schema, f.Name)
+	for _, existing := range l.functions[key] {
+		if existing == f {
+			return
+		}
+	}
+	l.functions[key] = append(l.functions[key], f)
+}
+
+// functionArgs counts input parameters, parameters with default value are
+// optional
+func functionArgs(params []*pg_query.Node) (int, int) {
+	minArgs, maxArgs := 0, 0
+	for _, node := range params {
+		param := node.GetFunctionParameter()
+		if param == nil {
+			continue
+		}
+		switch param.GetMode() {
+		case pg_query.FunctionParameterMode_FUNC_PARAM_OUT, pg_query.FunctionParameterMode_FUNC_PARAM_TABLE:
+			continue
+		case pg_query.FunctionParameterMode_FUNC_PARAM_VARIADIC:
+			// takes at least one value unless it has a default
+			if param.GetDefexpr() == nil {
+				minArgs = maxArgs + 1
+			}
+			return minArgs, -1
+		}
+		maxArgs++
+		if param.GetDefexpr() == nil {
+			minArgs = maxArgs
+		}
+	}
+	return minArgs, maxArgs
+}
+
+// applyCreateFunction applies CREATE FUNCTION, procedures are skipped since
+// they can only be invoked through CALL.
+func (l *pgSchemaLoader) applyCreateFunction(stmt *pg_query.CreateFunctionStmt, schemaName string) {
+	if stmt.GetIsProcedure() {
+		return
+	}
+	funcSchema, name := splitQualifiedName(stmt.GetFuncname())
+	if funcSchema == "" {
+		funcSchema = schemaName
+	}
+	funcSchema = l.creationSchema(funcSchema)
+	minArgs, maxArgs := functionArgs(stmt.GetParameters())
+	l.addFunction(Function{Name: name, Schema: funcSchema, MinArgs: minArgs, MaxArgs: maxArgs})
+}
+
+// applyCreateAggregate applies CREATE AGGREGATE name (arg_type, ...)
+func (l *pgSchemaLoader) applyCreateAggregate(stmt *pg_query.DefineStmt, schemaName string) {
+	aggSchema, name := splitQualifiedName(stmt.GetDefnames())
+	if aggSchema == "" {
+		aggSchema = schemaName
+	}
+	aggSchema = l.creationSchema(aggSchema)
+
+	f := Function{Name: name, Schema: aggSchema, MinArgs: 1, MaxArgs: 1}
+	if !stmt.GetOldstyle() {
+		// args is a list of parameters followed by number of direct
+		// arguments of ordered-set aggregates, aggregates taking `*` come
+		// without parameter list
+		args := stmt.GetArgs()
+		f.MinArgs, f.MaxArgs = 0, 0
+		if len(args) > 0 {
+			f.MinArgs, f.MaxArgs = functionArgs(args[0].GetList().GetItems())
+		}
+	}
+	l.addFunction(f)
+}
@@ -0,0 +1,147 @@
+//go:build ignore
+
+// gen_pgcatalog generates pgcatalog.go, names of pg_catalog functions taken
+// from fmgroids.h shipped with pg_query_go. Function OID macros there are
+// named F_<PRONAME>, overloaded functions get their argument types appended,
+// e.g. F_AGE_TIMESTAMP_TIMESTAMP, so these are stripped using type names
+// from pg_type_d.h and only the stripped names are kept.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/pganalyze/pg_query_go/v6").Output()
+	if err != nil {
+		log.Fatalf("failed to locate pg_query_go module: %v", err)
+	}
+	include := filepath.Join(strings.TrimSpace(string(out)), "parser", "include", "postgres")
+
+	macros := defines(filepath.Join(include, "utils", "fmgroids.h"), `#define F_(\w+) \d+`)
+	types := map[string]bool{}
+	for _, t := range defines(filepath.Join(include, "catalog", "pg_type_d.h"), `#define (\w+)OID \d+`) {
+		types[t] = true
+		if elem, ok := strings.CutSuffix(t, "ARRAY"); ok {
+			types["_"+elem] = true
+		}
+	}
+
+	// C functions are named after the function they implement, so such
+	// macros are kept even if they look like an overload, e.g.
+	// F_HAS_TABLE_PRIVILEGE_NAME
+	cfuncs := map[string]bool{}
+	for _, f := range defines(filepath.Join(include, "utils", "fmgrprotos.h"), `extern Datum (\w+)\(`) {
+		cfuncs[strings.ToUpper(f)] = true
+	}
+
+	// overloads maps function names to their overload macros, names with
+	// a single overload are not overloaded functions, e.g. ARRAY_TO of
+	// ARRAY_TO_TSVECTOR
+	overloads := map[string][]string{}
+	for _, m := range macros {
+		parts := strings.Split(m, "_")
+		for i := 1; i < len(parts); i++ {
+			if isTypeList(parts[i:], types) {
+				prefix := strings.Join(parts[:i], "_")
+				overloads[prefix] = append(overloads[prefix], m)
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for prefix, members := range overloads {
+		if len(members) > 1 {
+			names[strings.ToLower(prefix)] = true
+		}
+	}
+	for _, m := range macros {
+		if !cfuncs[m] && isOverload(m, overloads, types) {
+			continue
+		}
+		// zero argument overloads end with _
+		names[strings.ToLower(strings.TrimSuffix(m, "_"))] = true
+	}
+
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_pgcatalog.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package schema\n\n")
+	buf.WriteString("// pgCatalogFunctions holds names of functions and aggregates in pg_catalog\n")
+	buf.WriteString("var pgCatalogFunctions = map[string]bool{\n")
+	for _, name := range sorted {
+		fmt.Fprintf(&buf, "\t%q: true,\n", name)
+	}
+	buf.WriteString("}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("pgcatalog.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// defines returns names captured by pattern from a header file
+func defines(path string, pattern string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := []string{}
+	for _, m := range regexp.MustCompile(pattern).FindAllStringSubmatch(string(content), -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// isOverload reports whether macro is an overloaded function name followed
+// by a list of argument types
+func isOverload(macro string, overloads map[string][]string, types map[string]bool) bool {
+	parts := strings.Split(macro, "_")
+	for i := 1; i < len(parts); i++ {
+		if len(overloads[strings.Join(parts[:i], "_")]) > 1 && isTypeList(parts[i:], types) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTypeList reports whether parts split by _ can be joined back into a
+// list of type names, array types are prefixed with _ themselves. A single
+// empty part is the empty list of a zero argument overload.
+func isTypeList(parts []string, types map[string]bool) bool {
+	if len(parts) == 1 && parts[0] == "" {
+		return true
+	}
+	ok := make([]bool, len(parts)+1)
+	ok[0] = true
+	for i := range parts {
+		if !ok[i] {
+			continue
+		}
+		for j := i + 1; j <= len(parts); j++ {
+			if types[strings.Join(parts[i:j], "_")] {
+				ok[j] = true
+			}
+			if parts[i] == "" && j > i+1 && types["_"+strings.Join(parts[i+1:j], "_")] {
+				ok[j] = true
+			}
+		}
+	}
+	return ok[len(parts)]
+}
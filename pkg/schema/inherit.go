@@ -0,0 +1,80 @@
+package schema
+
+import (
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
+// CREATE TABLE ... (LIKE other INCLUDING ...) options, bits of
+// TableLikeClause.Options as defined in postgres' parsenodes.h
+const (
+	tableLikeDefaults  = 1 << 3
+	tableLikeGenerated = 1 << 4
+	tableLikeIdentity  = 1 << 5
+)
+
+// inheritColumns copies columns of parent into table. Partitions get parent
+// columns as is, identity is not inherited through INHERITS.
+func (l *pgSchemaLoader) inheritColumns(table Table, parent *pg_query.RangeVar, partition bool) {
+	key, ok := l.lookup(parent)
+	if !ok {
+		return
+	}
+	parentTable := l.tables[key]
+	for _, name := range parentTable.ColumnNames() {
+		col := parentTable.Columns[name]
+		if col.Identity && !partition {
+			col.HasDefault = false
+			col.Identity = false
+			col.GeneratedAlways = false
+		}
+		table.addColumn(col)
+	}
+}
+
+// copyLikeColumns copies column names, types and NOT NULL constraints of the
+// table in LIKE clause, defaults, generated and identity columns are only
+// copied with matching INCLUDING options.
+func (l *pgSchemaLoader) copyLikeColumns(table Table, like *pg_query.TableLikeClause) {
+	key, ok := l.lookup(like.GetRelation())
+	if !ok {
+		return
+	}
+	options := like.GetOptions()
+	likeTable := l.tables[key]
+	for _, name := range likeTable.ColumnNames() {
+		col := likeTable.Columns[name]
+		switch {
+		case col.Identity:
+			if options&tableLikeIdentity == 0 {
+				col.HasDefault = false
+				col.Identity = false
+				col.GeneratedAlways = false
+			}
+		case col.GeneratedAlways:
+			if options&tableLikeGenerated == 0 {
+				col.HasDefault = false
+				col.GeneratedAlways = false
+			}
+		case col.HasDefault:
+			// serial columns are backed by a sequence default
+			col.HasDefault = options&tableLikeDefaults != 0
+		}
+		table.addColumn(col)
+	}
+}
+
+// addColumnDef adds a column defined in CREATE TABLE. Columns already
+// inherited from a parent are merged, partitions can only add constraints to
+// them, e.g. PARTITION OF parent (id NOT NULL).
+func (l *pgSchemaLoader) addColumnDef(table Table, colDef *pg_query.ColumnDef) {
+	existing, ok := table.Columns[colDef.GetColname()]
+	if !ok {
+		table.addColumn(l.parseColumnDef(colDef))
+		return
+	}
+	if colDef.GetIsNotNull() {
+		existing.NotNull = true
+	}
+	applyColumnConstraints(&existing, colDef.GetConstraints())
+	table.Columns[existing.Name] = existing
+}
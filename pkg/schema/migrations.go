@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migration is the up part of a migration file
+type migration struct {
+	Version uint64
+	Name    string
+	Up      string
+}
+
+// migrationVersion parses version prefix of migration file names, e.g.
+// 20240102150405 from 20240102150405_add_users.up.sql or 3 from
+// 00003_add_users.sql.
+func migrationVersion(name string) (uint64, bool) {
+	end := strings.IndexFunc(name, func(r rune) bool { return r < '0' || r > '9' })
+	if end <= 0 {
+		return 0, false
+	}
+	version, err := strconv.ParseUint(name[:end], 10, 64)
+	return version, err == nil
+}
+
+// gooseUpSection returns statements between `-- +goose Up` and
+// `-- +goose Down` annotations. Files without goose annotations are
+// returned as is.
+func gooseUpSection(content string) string {
+	if !strings.Contains(content, "+goose Up") {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	inUp := false
+	for i, line := range lines {
+		if annotation, ok := strings.CutPrefix(strings.TrimSpace(line), "--"); ok {
+			switch strings.TrimSpace(annotation) {
+			case "+goose Up":
+				inUp = true
+			case "+goose Down":
+				inUp = false
+			}
+		}
+		if !inUp {
+			// keep line numbers of up statements intact
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// readMigrations reads up migrations from dir ordered by version. Both
+// golang-migrate style *.up.sql/*.down.sql pairs and goose style files with
+// Up/Down annotations are supported.
+func readMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []migration{}
+	versions := map[uint64]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") || strings.HasSuffix(name, ".down.sql") {
+			continue
+		}
+		version, ok := migrationVersion(name)
+		if !ok {
+			return nil, fmt.Errorf("migration %s doesn't have a version prefix", name)
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, name, version)
+		}
+		versions[version] = name
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{
+			Version: version,
+			Name:    name,
+			Up:      gooseUpSection(string(content)),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// applyMigrations replays up migrations in dir in version order
+func (l *pgSchemaLoader) applyMigrations(dir string) error {
+	migrations, err := readMigrations(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if l.exclude[absPath(filepath.Join(dir, m.Name))] {
+			continue
+		}
+		if err := l.apply(m.Up); err != nil {
+			return fmt.Errorf("migration %s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeMigrations(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func Test_readMigrations(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"10_add_email.up.sql":   "ALTER TABLE users ADD COLUMN email text;",
+		"10_add_email.down.sql": "ALTER TABLE users DROP COLUMN email;",
+		"2_create_users.sql": `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (id int);
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE users;
+`,
+		"README.md": "migrations",
+	})
+
+	migrations, err := readMigrations(dir)
+	require.NoError(t, err)
+	require.Equal(t, []migration{
+		{
+			Version: 2,
+			Name:    "2_create_users.sql",
+			Up:      "-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE users (id int);\n-- +goose StatementEnd\n\n\n\n",
+		},
+		{
+			Version: 10,
+			Name:    "10_add_email.up.sql",
+			Up:      "ALTER TABLE users ADD COLUMN email text;",
+		},
+	}, migrations)
+}
+
+func Test_readMigrationsInvalid(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"schema.sql": "CREATE TABLE users (id int);",
+	})
+	_, err := readMigrations(dir)
+	require.EqualError(t, err, "migration schema.sql doesn't have a version prefix")
+
+	dir = writeMigrations(t, map[string]string{
+		"001_users.up.sql": "CREATE TABLE users (id int);",
+		"001_posts.up.sql": "CREATE TABLE posts (id int);",
+	})
+	_, err = readMigrations(dir)
+	require.EqualError(t, err, "migrations 001_posts.up.sql and 001_users.up.sql have the same version 1")
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"001_users.up.sql":    "CREATE TABLE users (id int, name text);",
+		"002_rename.up.sql":   "ALTER TABLE users RENAME COLUMN name TO full_name;",
+		"002_rename.down.sql": "ALTER TABLE users RENAME COLUMN full_name TO name;",
+		"003_broken.up.sql":   "ALTER TABLE users ADD COLUMN;",
+		"004_posts.up.sql":    "CREATE TABLE posts (id int);",
+	})
+	_, err := NewDbSchema(dir)
+	require.ErrorContains(t, err, "migration 003_broken.up.sql: ")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "003_broken.up.sql")))
+	db, err := NewDbSchema(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"users", "posts"}, []string{db.Tables["users"].Name, db.Tables["posts"].Name})
+	require.Contains(t, db.Tables["users"].Columns, "full_name")
+	require.NotContains(t, db.Tables["users"].Columns, "name")
+}
@@ -0,0 +1,2953 @@
+// Code generated by gen_pgcatalog.go; DO NOT EDIT.
+
+package schema
+
+// pgCatalogFunctions holds names of functions and aggregates in pg_catalog
+var pgCatalogFunctions = map[string]bool{
+	"abbrev":                                true,
+	"abs":                                   true,
+	"aclcontains":                           true,
+	"acldefault":                            true,
+	"aclexplode":                            true,
+	"aclinsert":                             true,
+	"aclitemeq":                             true,
+	"aclitemin":                             true,
+	"aclitemout":                            true,
+	"aclremove":                             true,
+	"acos":                                  true,
+	"acosd":                                 true,
+	"acosh":                                 true,
+	"age":                                   true,
+	"amvalidate":                            true,
+	"any_in":                                true,
+	"any_out":                               true,
+	"any_value":                             true,
+	"any_value_transfn":                     true,
+	"anyarray_in":                           true,
+	"anyarray_out":                          true,
+	"anyarray_recv":                         true,
+	"anyarray_send":                         true,
+	"anycompatible_in":                      true,
+	"anycompatible_out":                     true,
+	"anycompatiblearray_in":                 true,
+	"anycompatiblearray_out":                true,
+	"anycompatiblearray_recv":               true,
+	"anycompatiblearray_send":               true,
+	"anycompatiblemultirange_in":            true,
+	"anycompatiblemultirange_out":           true,
+	"anycompatiblenonarray_in":              true,
+	"anycompatiblenonarray_out":             true,
+	"anycompatiblerange_in":                 true,
+	"anycompatiblerange_out":                true,
+	"anyelement_in":                         true,
+	"anyelement_out":                        true,
+	"anyenum_in":                            true,
+	"anyenum_out":                           true,
+	"anymultirange_in":                      true,
+	"anymultirange_out":                     true,
+	"anynonarray_in":                        true,
+	"anynonarray_out":                       true,
+	"anyrange_in":                           true,
+	"anyrange_out":                          true,
+	"anytextcat":                            true,
+	"area":                                  true,
+	"areajoinsel":                           true,
+	"areasel":                               true,
+	"array_agg":                             true,
+	"array_agg_array_combine":               true,
+	"array_agg_array_deserialize":           true,
+	"array_agg_array_finalfn":               true,
+	"array_agg_array_serialize":             true,
+	"array_agg_array_transfn":               true,
+	"array_agg_combine":                     true,
+	"array_agg_deserialize":                 true,
+	"array_agg_finalfn":                     true,
+	"array_agg_serialize":                   true,
+	"array_agg_transfn":                     true,
+	"array_append":                          true,
+	"array_cat":                             true,
+	"array_dims":                            true,
+	"array_eq":                              true,
+	"array_fill":                            true,
+	"array_fill_anyelement":                 true,
+	"array_fill_anyelement_":                true,
+	"array_ge":                              true,
+	"array_gt":                              true,
+	"array_in":                              true,
+	"array_larger":                          true,
+	"array_le":                              true,
+	"array_length":                          true,
+	"array_lower":                           true,
+	"array_lt":                              true,
+	"array_ndims":                           true,
+	"array_ne":                              true,
+	"array_out":                             true,
+	"array_position":                        true,
+	"array_position_anycompatiblearray":     true,
+	"array_positions":                       true,
+	"array_prepend":                         true,
+	"array_recv":                            true,
+	"array_remove":                          true,
+	"array_replace":                         true,
+	"array_sample":                          true,
+	"array_send":                            true,
+	"array_shuffle":                         true,
+	"array_smaller":                         true,
+	"array_subscript_handler":               true,
+	"array_to":                              true,
+	"array_to_json":                         true,
+	"array_to_string":                       true,
+	"array_to_string_anyarray":              true,
+	"array_to_tsvector":                     true,
+	"array_typanalyze":                      true,
+	"array_unnest_support":                  true,
+	"array_upper":                           true,
+	"arraycontained":                        true,
+	"arraycontains":                         true,
+	"arraycontjoinsel":                      true,
+	"arraycontsel":                          true,
+	"arrayoverlap":                          true,
+	"ascii":                                 true,
+	"asin":                                  true,
+	"asind":                                 true,
+	"asinh":                                 true,
+	"atan":                                  true,
+	"atan2":                                 true,
+	"atan2d":                                true,
+	"atand":                                 true,
+	"atanh":                                 true,
+	"avg":                                   true,
+	"bernoulli":                             true,
+	"big5_to_euc_tw":                        true,
+	"big5_to_mic":                           true,
+	"big5_to_utf8":                          true,
+	"binary_upgrade_add_sub_rel_state":      true,
+	"binary_upgrade_create_empty_extension": true,
+	"binary_upgrade_logical_slot_has_caught_up":            true,
+	"binary_upgrade_replorigin_advance":                    true,
+	"binary_upgrade_set_missing_value":                     true,
+	"binary_upgrade_set_next_array_pg_type_oid":            true,
+	"binary_upgrade_set_next_heap_pg_class_oid":            true,
+	"binary_upgrade_set_next_heap_relfilenode":             true,
+	"binary_upgrade_set_next_index_pg_class_oid":           true,
+	"binary_upgrade_set_next_index_relfilenode":            true,
+	"binary_upgrade_set_next_multirange_array_pg_type_oid": true,
+	"binary_upgrade_set_next_multirange_pg_type_oid":       true,
+	"binary_upgrade_set_next_pg_authid_oid":                true,
+	"binary_upgrade_set_next_pg_enum_oid":                  true,
+	"binary_upgrade_set_next_pg_tablespace_oid":            true,
+	"binary_upgrade_set_next_pg_type_oid":                  true,
+	"binary_upgrade_set_next_toast_pg_class_oid":           true,
+	"binary_upgrade_set_next_toast_relfilenode":            true,
+	"binary_upgrade_set_record_init_privs":                 true,
+	"bit":                                                  true,
+	"bit_and":                                              true,
+	"bit_count":                                            true,
+	"bit_in":                                               true,
+	"bit_length":                                           true,
+	"bit_or":                                               true,
+	"bit_out":                                              true,
+	"bit_recv":                                             true,
+	"bit_send":                                             true,
+	"bit_xor":                                              true,
+	"bitand":                                               true,
+	"bitcat":                                               true,
+	"bitcmp":                                               true,
+	"biteq":                                                true,
+	"bitge":                                                true,
+	"bitgt":                                                true,
+	"bitle":                                                true,
+	"bitlt":                                                true,
+	"bitne":                                                true,
+	"bitnot":                                               true,
+	"bitor":                                                true,
+	"bitshiftleft":                                         true,
+	"bitshiftright":                                        true,
+	"bittypmodin":                                          true,
+	"bittypmodout":                                         true,
+	"bitxor":                                               true,
+	"bool":                                                 true,
+	"bool_accum":                                           true,
+	"bool_accum_inv":                                       true,
+	"bool_alltrue":                                         true,
+	"bool_and":                                             true,
+	"bool_anytrue":                                         true,
+	"bool_int4":                                            true,
+	"bool_or":                                              true,
+	"booland_statefunc":                                    true,
+	"booleq":                                               true,
+	"boolge":                                               true,
+	"boolgt":                                               true,
+	"boolin":                                               true,
+	"boolle":                                               true,
+	"boollt":                                               true,
+	"boolne":                                               true,
+	"boolor_statefunc":                                     true,
+	"boolout":                                              true,
+	"boolrecv":                                             true,
+	"boolsend":                                             true,
+	"bound_box":                                            true,
+	"box":                                                  true,
+	"box_above":                                            true,
+	"box_above_eq":                                         true,
+	"box_add":                                              true,
+	"box_below":                                            true,
+	"box_below_eq":                                         true,
+	"box_center":                                           true,
+	"box_circle":                                           true,
+	"box_contain":                                          true,
+	"box_contain_pt":                                       true,
+	"box_contained":                                        true,
+	"box_distance":                                         true,
+	"box_div":                                              true,
+	"box_eq":                                               true,
+	"box_ge":                                               true,
+	"box_gt":                                               true,
+	"box_in":                                               true,
+	"box_intersect":                                        true,
+	"box_le":                                               true,
+	"box_left":                                             true,
+	"box_lt":                                               true,
+	"box_mul":                                              true,
+	"box_out":                                              true,
+	"box_overabove":                                        true,
+	"box_overbelow":                                        true,
+	"box_overlap":                                          true,
+	"box_overleft":                                         true,
+	"box_overright":                                        true,
+	"box_recv":                                             true,
+	"box_right":                                            true,
+	"box_same":                                             true,
+	"box_send":                                             true,
+	"box_sub":                                              true,
+	"bpchar":                                               true,
+	"bpchar_larger":                                        true,
+	"bpchar_name":                                          true,
+	"bpchar_pattern_ge":                                    true,
+	"bpchar_pattern_gt":                                    true,
+	"bpchar_pattern_le":                                    true,
+	"bpchar_pattern_lt":                                    true,
+	"bpchar_smaller":                                       true,
+	"bpchar_sortsupport":                                   true,
+	"bpcharcmp":                                            true,
+	"bpchareq":                                             true,
+	"bpcharge":                                             true,
+	"bpchargt":                                             true,
+	"bpchariclike":                                         true,
+	"bpcharicnlike":                                        true,
+	"bpcharicregexeq":                                      true,
+	"bpcharicregexne":                                      true,
+	"bpcharin":                                             true,
+	"bpcharle":                                             true,
+	"bpcharlike":                                           true,
+	"bpcharlt":                                             true,
+	"bpcharne":                                             true,
+	"bpcharnlike":                                          true,
+	"bpcharout":                                            true,
+	"bpcharrecv":                                           true,
+	"bpcharregexeq":                                        true,
+	"bpcharregexne":                                        true,
+	"bpcharsend":                                           true,
+	"bpchartypmodin":                                       true,
+	"bpchartypmodout":                                      true,
+	"brin_bloom_add_value":                                 true,
+	"brin_bloom_consistent":                                true,
+	"brin_bloom_opcinfo":                                   true,
+	"brin_bloom_options":                                   true,
+	"brin_bloom_summary_in":                                true,
+	"brin_bloom_summary_out":                               true,
+	"brin_bloom_summary_recv":                              true,
+	"brin_bloom_summary_send":                              true,
+	"brin_bloom_union":                                     true,
+	"brin_desummarize_range":                               true,
+	"brin_inclusion_add_value":                             true,
+	"brin_inclusion_consistent":                            true,
+	"brin_inclusion_opcinfo":                               true,
+	"brin_inclusion_union":                                 true,
+	"brin_minmax_add_value":                                true,
+	"brin_minmax_consistent":                               true,
+	"brin_minmax_multi_add_value":                          true,
+	"brin_minmax_multi_consistent":                         true,
+	"brin_minmax_multi_distance":                           true,
+	"brin_minmax_multi_distance_date":                      true,
+	"brin_minmax_multi_distance_float4":                    true,
+	"brin_minmax_multi_distance_float8":                    true,
+	"brin_minmax_multi_distance_inet":                      true,
+	"brin_minmax_multi_distance_int2":                      true,
+	"brin_minmax_multi_distance_int4":                      true,
+	"brin_minmax_multi_distance_int8":                      true,
+	"brin_minmax_multi_distance_interval":                  true,
+	"brin_minmax_multi_distance_macaddr":                   true,
+	"brin_minmax_multi_distance_macaddr8":                  true,
+	"brin_minmax_multi_distance_numeric":                   true,
+	"brin_minmax_multi_distance_pg_lsn":                    true,
+	"brin_minmax_multi_distance_tid":                       true,
+	"brin_minmax_multi_distance_time":                      true,
+	"brin_minmax_multi_distance_timestamp":                 true,
+	"brin_minmax_multi_distance_timetz":                    true,
+	"brin_minmax_multi_distance_uuid":                      true,
+	"brin_minmax_multi_opcinfo":                            true,
+	"brin_minmax_multi_options":                            true,
+	"brin_minmax_multi_summary_in":                         true,
+	"brin_minmax_multi_summary_out":                        true,
+	"brin_minmax_multi_summary_recv":                       true,
+	"brin_minmax_multi_summary_send":                       true,
+	"brin_minmax_multi_union":                              true,
+	"brin_minmax_opcinfo":                                  true,
+	"brin_minmax_union":                                    true,
+	"brin_summarize_new_values":                            true,
+	"brin_summarize_range":                                 true,
+	"brinhandler":                                          true,
+	"broadcast":                                            true,
+	"btarraycmp":                                           true,
+	"btboolcmp":                                            true,
+	"btbpchar_pattern_cmp":                                 true,
+	"btbpchar_pattern_sortsupport":                         true,
+	"btcharcmp":                                            true,
+	"btequalimage":                                         true,
+	"btfloat48cmp":                                         true,
+	"btfloat4cmp":                                          true,
+	"btfloat4sortsupport":                                  true,
+	"btfloat84cmp":                                         true,
+	"btfloat8cmp":                                          true,
+	"btfloat8sortsupport":                                  true,
+	"bthandler":                                            true,
+	"btint24cmp":                                           true,
+	"btint28cmp":                                           true,
+	"btint2cmp":                                            true,
+	"btint2sortsupport":                                    true,
+	"btint42cmp":                                           true,
+	"btint48cmp":                                           true,
+	"btint4cmp":                                            true,
+	"btint4sortsupport":                                    true,
+	"btint82cmp":                                           true,
+	"btint84cmp":                                           true,
+	"btint8cmp":                                            true,
+	"btint8sortsupport":                                    true,
+	"btnamecmp":                                            true,
+	"btnamesortsupport":                                    true,
+	"btnametextcmp":                                        true,
+	"btoidcmp":                                             true,
+	"btoidsortsupport":                                     true,
+	"btoidvectorcmp":                                       true,
+	"btrecordcmp":                                          true,
+	"btrecordimagecmp":                                     true,
+	"btrim":                                                true,
+	"bttext_pattern_cmp":                                   true,
+	"bttext_pattern_sortsupport":                           true,
+	"bttextcmp":                                            true,
+	"bttextnamecmp":                                        true,
+	"bttextsortsupport":                                    true,
+	"bttidcmp":                                             true,
+	"btvarstrequalimage":                                   true,
+	"bytea_sortsupport":                                    true,
+	"bytea_string_agg_finalfn":                             true,
+	"bytea_string_agg_transfn":                             true,
+	"byteacat":                                             true,
+	"byteacmp":                                             true,
+	"byteaeq":                                              true,
+	"byteage":                                              true,
+	"byteagt":                                              true,
+	"byteain":                                              true,
+	"byteale":                                              true,
+	"bytealike":                                            true,
+	"bytealt":                                              true,
+	"byteane":                                              true,
+	"byteanlike":                                           true,
+	"byteaout":                                             true,
+	"bytearecv":                                            true,
+	"byteasend":                                            true,
+	"cardinality":                                          true,
+	"cash_cmp":                                             true,
+	"cash_div":                                             true,
+	"cash_div_cash":                                        true,
+	"cash_div_flt4":                                        true,
+	"cash_div_flt8":                                        true,
+	"cash_div_int2":                                        true,
+	"cash_div_int4":                                        true,
+	"cash_div_int8":                                        true,
+	"cash_eq":                                              true,
+	"cash_ge":                                              true,
+	"cash_gt":                                              true,
+	"cash_in":                                              true,
+	"cash_le":                                              true,
+	"cash_lt":                                              true,
+	"cash_mi":                                              true,
+	"cash_mul":                                             true,
+	"cash_mul_flt4":                                        true,
+	"cash_mul_flt8":                                        true,
+	"cash_mul_int2":                                        true,
+	"cash_mul_int4":                                        true,
+	"cash_mul_int8":                                        true,
+	"cash_ne":                                              true,
+	"cash_out":                                             true,
+	"cash_pl":                                              true,
+	"cash_recv":                                            true,
+	"cash_send":                                            true,
+	"cash_words":                                           true,
+	"cashlarger":                                           true,
+	"cashsmaller":                                          true,
+	"cbrt":                                                 true,
+	"ceil":                                                 true,
+	"ceiling":                                              true,
+	"center":                                               true,
+	"char":                                                 true,
+	"char_length":                                          true,
+	"char_text":                                            true,
+	"character_length":                                     true,
+	"chareq":                                               true,
+	"charge":                                               true,
+	"chargt":                                               true,
+	"charin":                                               true,
+	"charle":                                               true,
+	"charlt":                                               true,
+	"charne":                                               true,
+	"charout":                                              true,
+	"charrecv":                                             true,
+	"charsend":                                             true,
+	"chr":                                                  true,
+	"cideq":                                                true,
+	"cidin":                                                true,
+	"cidout":                                               true,
+	"cidr":                                                 true,
+	"cidr_in":                                              true,
+	"cidr_out":                                             true,
+	"cidr_recv":                                            true,
+	"cidr_send":                                            true,
+	"cidrecv":                                              true,
+	"cidsend":                                              true,
+	"circle":                                               true,
+	"circle_above":                                         true,
+	"circle_add_pt":                                        true,
+	"circle_below":                                         true,
+	"circle_box":                                           true,
+	"circle_center":                                        true,
+	"circle_contain":                                       true,
+	"circle_contain_pt":                                    true,
+	"circle_contained":                                     true,
+	"circle_distance":                                      true,
+	"circle_div_pt":                                        true,
+	"circle_eq":                                            true,
+	"circle_ge":                                            true,
+	"circle_gt":                                            true,
+	"circle_in":                                            true,
+	"circle_le":                                            true,
+	"circle_left":                                          true,
+	"circle_lt":                                            true,
+	"circle_mul_pt":                                        true,
+	"circle_ne":                                            true,
+	"circle_out":                                           true,
+	"circle_overabove":                                     true,
+	"circle_overbelow":                                     true,
+	"circle_overlap":                                       true,
+	"circle_overleft":                                      true,
+	"circle_overright":                                     true,
+	"circle_recv":                                          true,
+	"circle_right":                                         true,
+	"circle_same":                                          true,
+	"circle_send":                                          true,
+	"circle_sub_pt":                                        true,
+	"clock_timestamp":                                      true,
+	"close_ls":                                             true,
+	"close_lseg":                                           true,
+	"close_pb":                                             true,
+	"close_pl":                                             true,
+	"close_ps":                                             true,
+	"close_sb":                                             true,
+	"col_description":                                      true,
+	"concat":                                               true,
+	"concat_ws":                                            true,
+	"contjoinsel":                                          true,
+	"contsel":                                              true,
+	"convert":                                              true,
+	"convert_from":                                         true,
+	"convert_to":                                           true,
+	"corr":                                                 true,
+	"cos":                                                  true,
+	"cosd":                                                 true,
+	"cosh":                                                 true,
+	"cot":                                                  true,
+	"cotd":                                                 true,
+	"count":                                                true,
+	"covar_pop":                                            true,
+	"covar_samp":                                           true,
+	"cstring_in":                                           true,
+	"cstring_out":                                          true,
+	"cstring_recv":                                         true,
+	"cstring_send":                                         true,
+	"cume_dist":                                            true,
+	"cume_dist_final":                                      true,
+	"current_database":                                     true,
+	"current_query":                                        true,
+	"current_schema":                                       true,
+	"current_schemas":                                      true,
+	"current_setting":                                      true,
+	"current_user":                                         true,
+	"currtid2":                                             true,
+	"currval":                                              true,
+	"cursor_to_xml":                                        true,
+	"cursor_to_xmlschema":                                  true,
+	"database_to_xml":                                      true,
+	"database_to_xml_and_xmlschema":                        true,
+	"database_to_xmlschema":                                true,
+	"date":                                                 true,
+	"date_add":                                             true,
+	"date_add_timestamptz":                                 true,
+	"date_bin":                                             true,
+	"date_bin_interval":                                    true,
+	"date_cmp":                                             true,
+	"date_cmp_timestamp":                                   true,
+	"date_cmp_timestamptz":                                 true,
+	"date_eq":                                              true,
+	"date_eq_timestamp":                                    true,
+	"date_eq_timestamptz":                                  true,
+	"date_ge":                                              true,
+	"date_ge_timestamp":                                    true,
+	"date_ge_timestamptz":                                  true,
+	"date_gt":                                              true,
+	"date_gt_timestamp":                                    true,
+	"date_gt_timestamptz":                                  true,
+	"date_in":                                              true,
+	"date_larger":                                          true,
+	"date_le":                                              true,
+	"date_le_timestamp":                                    true,
+	"date_le_timestamptz":                                  true,
+	"date_lt":                                              true,
+	"date_lt_timestamp":                                    true,
+	"date_lt_timestamptz":                                  true,
+	"date_mi":                                              true,
+	"date_mi_interval":                                     true,
+	"date_mii":                                             true,
+	"date_ne":                                              true,
+	"date_ne_timestamp":                                    true,
+	"date_ne_timestamptz":                                  true,
+	"date_out":                                             true,
+	"date_part":                                            true,
+	"date_part_text":                                       true,
+	"date_pl_interval":                                     true,
+	"date_pli":                                             true,
+	"date_recv":                                            true,
+	"date_send":                                            true,
+	"date_smaller":                                         true,
+	"date_sortsupport":                                     true,
+	"date_subtract":                                        true,
+	"date_subtract_timestamptz":                            true,
+	"date_timestamp":                                       true,
+	"date_timestamptz":                                     true,
+	"date_trunc":                                           true,
+	"date_trunc_text":                                      true,
+	"datemultirange":                                       true,
+	"daterange":                                            true,
+	"daterange_canonical":                                  true,
+	"daterange_date":                                       true,
+	"daterange_subdiff":                                    true,
+	"datetime_pl":                                          true,
+	"datetimetz_pl":                                        true,
+	"dcbrt":                                                true,
+	"decode":                                               true,
+	"degrees":                                              true,
+	"dense_rank":                                           true,
+	"dense_rank_final":                                     true,
+	"dexp":                                                 true,
+	"diagonal":                                             true,
+	"diameter":                                             true,
+	"dispell_init":                                         true,
+	"dispell_lexize":                                       true,
+	"dist_bp":                                              true,
+	"dist_bs":                                              true,
+	"dist_cpoint":                                          true,
+	"dist_cpoly":                                           true,
+	"dist_lp":                                              true,
+	"dist_ls":                                              true,
+	"dist_pathp":                                           true,
+	"dist_pb":                                              true,
+	"dist_pc":                                              true,
+	"dist_pl":                                              true,
+	"dist_polyc":                                           true,
+	"dist_polyp":                                           true,
+	"dist_ppath":                                           true,
+	"dist_ppoly":                                           true,
+	"dist_ps":                                              true,
+	"dist_sb":                                              true,
+	"dist_sl":                                              true,
+	"dist_sp":                                              true,
+	"div":                                                  true,
+	"dlog1":                                                true,
+	"dlog10":                                               true,
+	"domain_in":                                            true,
+	"domain_recv":                                          true,
+	"dpow":                                                 true,
+	"dround":                                               true,
+	"dsimple_init":                                         true,
+	"dsimple_lexize":                                       true,
+	"dsqrt":                                                true,
+	"dsynonym_init":                                        true,
+	"dsynonym_lexize":                                      true,
+	"dtrunc":                                               true,
+	"elem_contained_by_multirange":                         true,
+	"elem_contained_by_range":                              true,
+	"elem_contained_by_range_support":                      true,
+	"encode":                                               true,
+	"enum_cmp":                                             true,
+	"enum_eq":                                              true,
+	"enum_first":                                           true,
+	"enum_ge":                                              true,
+	"enum_gt":                                              true,
+	"enum_in":                                              true,
+	"enum_larger":                                          true,
+	"enum_last":                                            true,
+	"enum_le":                                              true,
+	"enum_lt":                                              true,
+	"enum_ne":                                              true,
+	"enum_out":                                             true,
+	"enum_range":                                           true,
+	"enum_recv":                                            true,
+	"enum_send":                                            true,
+	"enum_smaller":                                         true,
+	"eqjoinsel":                                            true,
+	"eqsel":                                                true,
+	"erf":                                                  true,
+	"erfc":                                                 true,
+	"euc_cn_to_mic":                                        true,
+	"euc_cn_to_utf8":                                       true,
+	"euc_jis_2004_to_shift_jis_2004":                       true,
+	"euc_jis_2004_to_utf8":                                 true,
+	"euc_jp_to_mic":                                        true,
+	"euc_jp_to_sjis":                                       true,
+	"euc_jp_to_utf8":                                       true,
+	"euc_kr_to_mic":                                        true,
+	"euc_kr_to_utf8":                                       true,
+	"euc_tw_to_big5":                                       true,
+	"euc_tw_to_mic":                                        true,
+	"euc_tw_to_utf8":                                       true,
+	"event_trigger_in":                                     true,
+	"event_trigger_out":                                    true,
+	"every":                                                true,
+	"exp":                                                  true,
+	"extract":                                              true,
+	"extract_text":                                         true,
+	"factorial":                                            true,
+	"family":                                               true,
+	"fdw_handler_in":                                       true,
+	"fdw_handler_out":                                      true,
+	"first_value":                                          true,
+	"float4":                                               true,
+	"float48div":                                           true,
+	"float48eq":                                            true,
+	"float48ge":                                            true,
+	"float48gt":                                            true,
+	"float48le":                                            true,
+	"float48lt":                                            true,
+	"float48mi":                                            true,
+	"float48mul":                                           true,
+	"float48ne":                                            true,
+	"float48pl":                                            true,
+	"float4_accum":                                         true,
+	"float4_numeric":                                       true,
+	"float4abs":                                            true,
+	"float4div":                                            true,
+	"float4eq":                                             true,
+	"float4ge":                                             true,
+	"float4gt":                                             true,
+	"float4in":                                             true,
+	"float4larger":                                         true,
+	"float4le":                                             true,
+	"float4lt":                                             true,
+	"float4mi":                                             true,
+	"float4mul":                                            true,
+	"float4ne":                                             true,
+	"float4out":                                            true,
+	"float4pl":                                             true,
+	"float4recv":                                           true,
+	"float4send":                                           true,
+	"float4smaller":                                        true,
+	"float4um":                                             true,
+	"float4up":                                             true,
+	"float8":                                               true,
+	"float84div":                                           true,
+	"float84eq":                                            true,
+	"float84ge":                                            true,
+	"float84gt":                                            true,
+	"float84le":                                            true,
+	"float84lt":                                            true,
+	"float84mi":                                            true,
+	"float84mul":                                           true,
+	"float84ne":                                            true,
+	"float84pl":                                            true,
+	"float8_accum":                                         true,
+	"float8_avg":                                           true,
+	"float8_combine":                                       true,
+	"float8_corr":                                          true,
+	"float8_covar_pop":                                     true,
+	"float8_covar_samp":                                    true,
+	"float8_numeric":                                       true,
+	"float8_regr_accum":                                    true,
+	"float8_regr_avgx":                                     true,
+	"float8_regr_avgy":                                     true,
+	"float8_regr_combine":                                  true,
+	"float8_regr_intercept":                                true,
+	"float8_regr_r2":                                       true,
+	"float8_regr_slope":                                    true,
+	"float8_regr_sxx":                                      true,
+	"float8_regr_sxy":                                      true,
+	"float8_regr_syy":                                      true,
+	"float8_stddev_pop":                                    true,
+	"float8_stddev_samp":                                   true,
+	"float8_var_pop":                                       true,
+	"float8_var_samp":                                      true,
+	"float8abs":                                            true,
+	"float8div":                                            true,
+	"float8eq":                                             true,
+	"float8ge":                                             true,
+	"float8gt":                                             true,
+	"float8in":                                             true,
+	"float8larger":                                         true,
+	"float8le":                                             true,
+	"float8lt":                                             true,
+	"float8mi":                                             true,
+	"float8mul":                                            true,
+	"float8ne":                                             true,
+	"float8out":                                            true,
+	"float8pl":                                             true,
+	"float8recv":                                           true,
+	"float8send":                                           true,
+	"float8smaller":                                        true,
+	"float8um":                                             true,
+	"float8up":                                             true,
+	"floor":                                                true,
+	"flt4_mul_cash":                                        true,
+	"flt8_mul_cash":                                        true,
+	"fmgr_c_validator":                                     true,
+	"fmgr_internal_validator":                              true,
+	"fmgr_sql_validator":                                   true,
+	"format":                                               true,
+	"format_type":                                          true,
+	"gb18030_to_utf8":                                      true,
+	"gbk_to_utf8":                                          true,
+	"gcd":                                                  true,
+	"gen_random_uuid":                                      true,
+	"generate_series":                                      true,
+	"generate_series_int4":                                 true,
+	"generate_series_int4_support":                         true,
+	"generate_series_int8":                                 true,
+	"generate_series_int8_support":                         true,
+	"generate_series_numeric":                              true,
+	"generate_series_timestamptz":                          true,
+	"generate_series_timestamptz_timestamptz":   true,
+	"generate_subscripts":                       true,
+	"generate_subscripts_anyarray":              true,
+	"get":                                       true,
+	"get_bit":                                   true,
+	"get_byte":                                  true,
+	"get_current_ts_config":                     true,
+	"getdatabaseencoding":                       true,
+	"getpgusername":                             true,
+	"gin_clean_pending_list":                    true,
+	"gin_cmp_prefix":                            true,
+	"gin_cmp_tslexeme":                          true,
+	"gin_compare_jsonb":                         true,
+	"gin_consistent":                            true,
+	"gin_consistent_jsonb":                      true,
+	"gin_consistent_jsonb_path":                 true,
+	"gin_extract":                               true,
+	"gin_extract_jsonb":                         true,
+	"gin_extract_jsonb_path":                    true,
+	"gin_extract_jsonb_query":                   true,
+	"gin_extract_jsonb_query_path":              true,
+	"gin_extract_tsquery":                       true,
+	"gin_extract_tsquery_tsquery":               true,
+	"gin_extract_tsquery_tsquery_internal":      true,
+	"gin_extract_tsquery_tsquery_internal_int2": true,
+	"gin_extract_tsquery_tsquery_internal_int2_internal":         true,
+	"gin_extract_tsvector":                                       true,
+	"gin_extract_tsvector_tsvector":                              true,
+	"gin_triconsistent":                                          true,
+	"gin_triconsistent_jsonb":                                    true,
+	"gin_triconsistent_jsonb_path":                               true,
+	"gin_tsquery_consistent":                                     true,
+	"gin_tsquery_consistent_internal":                            true,
+	"gin_tsquery_consistent_internal_int2":                       true,
+	"gin_tsquery_consistent_internal_int2_tsquery":               true,
+	"gin_tsquery_consistent_internal_int2_tsquery_int4":          true,
+	"gin_tsquery_consistent_internal_int2_tsquery_int4_internal": true,
+	"gin_tsquery_triconsistent":                                  true,
+	"ginarrayconsistent":                                         true,
+	"ginarrayextract":                                            true,
+	"ginarrayextract_anyarray":                                   true,
+	"ginarraytriconsistent":                                      true,
+	"ginhandler":                                                 true,
+	"ginqueryarrayextract":                                       true,
+	"gist_box_consistent":                                        true,
+	"gist_box_distance":                                          true,
+	"gist_box_penalty":                                           true,
+	"gist_box_picksplit":                                         true,
+	"gist_box_same":                                              true,
+	"gist_box_union":                                             true,
+	"gist_circle_compress":                                       true,
+	"gist_circle_consistent":                                     true,
+	"gist_circle_distance":                                       true,
+	"gist_point_compress":                                        true,
+	"gist_point_consistent":                                      true,
+	"gist_point_distance":                                        true,
+	"gist_point_fetch":                                           true,
+	"gist_point_sortsupport":                                     true,
+	"gist_poly_compress":                                         true,
+	"gist_poly_consistent":                                       true,
+	"gist_poly_distance":                                         true,
+	"gisthandler":                                                true,
+	"gtsquery_compress":                                          true,
+	"gtsquery_consistent":                                        true,
+	"gtsquery_consistent_internal":                               true,
+	"gtsquery_penalty":                                           true,
+	"gtsquery_picksplit":                                         true,
+	"gtsquery_same":                                              true,
+	"gtsquery_union":                                             true,
+	"gtsvector_compress":                                         true,
+	"gtsvector_consistent":                                       true,
+	"gtsvector_consistent_internal":                              true,
+	"gtsvector_decompress":                                       true,
+	"gtsvector_options":                                          true,
+	"gtsvector_penalty":                                          true,
+	"gtsvector_picksplit":                                        true,
+	"gtsvector_same":                                             true,
+	"gtsvector_union":                                            true,
+	"gtsvectorin":                                                true,
+	"gtsvectorout":                                               true,
+	"has_any_column_privilege":                                   true,
+	"has_any_column_privilege_name":                              true,
+	"has_any_column_privilege_oid":                               true,
+	"has_column_privilege":                                       true,
+	"has_column_privilege_name":                                  true,
+	"has_column_privilege_name_oid":                              true,
+	"has_column_privilege_name_text":                             true,
+	"has_column_privilege_oid":                                   true,
+	"has_column_privilege_oid_oid":                               true,
+	"has_column_privilege_oid_text":                              true,
+	"has_column_privilege_text":                                  true,
+	"has_database_privilege":                                     true,
+	"has_database_privilege_name":                                true,
+	"has_database_privilege_oid":                                 true,
+	"has_foreign_data_wrapper_privilege":                         true,
+	"has_foreign_data_wrapper_privilege_name":                    true,
+	"has_foreign_data_wrapper_privilege_oid":                     true,
+	"has_function_privilege":                                     true,
+	"has_function_privilege_name":                                true,
+	"has_function_privilege_oid":                                 true,
+	"has_language_privilege":                                     true,
+	"has_language_privilege_name":                                true,
+	"has_language_privilege_oid":                                 true,
+	"has_parameter_privilege":                                    true,
+	"has_schema_privilege":                                       true,
+	"has_schema_privilege_name":                                  true,
+	"has_schema_privilege_oid":                                   true,
+	"has_sequence_privilege":                                     true,
+	"has_sequence_privilege_name":                                true,
+	"has_sequence_privilege_oid":                                 true,
+	"has_server_privilege":                                       true,
+	"has_server_privilege_name":                                  true,
+	"has_server_privilege_oid":                                   true,
+	"has_table_privilege":                                        true,
+	"has_table_privilege_name":                                   true,
+	"has_table_privilege_oid":                                    true,
+	"has_tablespace_privilege":                                   true,
+	"has_tablespace_privilege_name":                              true,
+	"has_tablespace_privilege_oid":                               true,
+	"has_type_privilege":                                         true,
+	"has_type_privilege_name":                                    true,
+	"has_type_privilege_oid":                                     true,
+	"hash":                                                       true,
+	"hash_aclitem":                                               true,
+	"hash_aclitem_extended":                                      true,
+	"hash_array":                                                 true,
+	"hash_array_extended":                                        true,
+	"hash_multirange":                                            true,
+	"hash_multirange_extended":                                   true,
+	"hash_numeric":                                               true,
+	"hash_numeric_extended":                                      true,
+	"hash_range":                                                 true,
+	"hash_range_extended":                                        true,
+	"hash_record":                                                true,
+	"hash_record_extended":                                       true,
+	"hashbpchar":                                                 true,
+	"hashbpcharextended":                                         true,
+	"hashchar":                                                   true,
+	"hashcharextended":                                           true,
+	"hashenum":                                                   true,
+	"hashenumextended":                                           true,
+	"hashfloat4":                                                 true,
+	"hashfloat4extended":                                         true,
+	"hashfloat8":                                                 true,
+	"hashfloat8extended":                                         true,
+	"hashhandler":                                                true,
+	"hashinet":                                                   true,
+	"hashinetextended":                                           true,
+	"hashint2":                                                   true,
+	"hashint2extended":                                           true,
+	"hashint4":                                                   true,
+	"hashint4extended":                                           true,
+	"hashint8":                                                   true,
+	"hashint8extended":                                           true,
+	"hashmacaddr":                                                true,
+	"hashmacaddr8":                                               true,
+	"hashmacaddr8extended":                                       true,
+	"hashmacaddrextended":                                        true,
+	"hashname":                                                   true,
+	"hashnameextended":                                           true,
+	"hashoid":                                                    true,
+	"hashoidextended":                                            true,
+	"hashoidvector":                                              true,
+	"hashoidvectorextended":                                      true,
+	"hashtext":                                                   true,
+	"hashtextextended":                                           true,
+	"hashtid":                                                    true,
+	"hashtidextended":                                            true,
+	"hashvarlena":                                                true,
+	"hashvarlenaextended":                                        true,
+	"heap_tableam_handler":                                       true,
+	"height":                                                     true,
+	"host":                                                       true,
+	"hostmask":                                                   true,
+	"iclikejoinsel":                                              true,
+	"iclikesel":                                                  true,
+	"icnlikejoinsel":                                             true,
+	"icnlikesel":                                                 true,
+	"icregexeqjoinsel":                                           true,
+	"icregexeqsel":                                               true,
+	"icregexnejoinsel":                                           true,
+	"icregexnesel":                                               true,
+	"icu_unicode_version":                                        true,
+	"in_range":                                                   true,
+	"in_range_int2":                                              true,
+	"in_range_int2_int2":                                         true,
+	"in_range_int4":                                              true,
+	"in_range_int4_int4":                                         true,
+	"index_am_handler_in":                                        true,
+	"index_am_handler_out":                                       true,
+	"inet_client_addr":                                           true,
+	"inet_client_port":                                           true,
+	"inet_gist_compress":                                         true,
+	"inet_gist_consistent":                                       true,
+	"inet_gist_fetch":                                            true,
+	"inet_gist_penalty":                                          true,
+	"inet_gist_picksplit":                                        true,
+	"inet_gist_same":                                             true,
+	"inet_gist_union":                                            true,
+	"inet_in":                                                    true,
+	"inet_merge":                                                 true,
+	"inet_out":                                                   true,
+	"inet_recv":                                                  true,
+	"inet_same_family":                                           true,
+	"inet_send":                                                  true,
+	"inet_server_addr":                                           true,
+	"inet_server_port":                                           true,
+	"inet_spg_choose":                                            true,
+	"inet_spg_config":                                            true,
+	"inet_spg_inner_consistent":                                  true,
+	"inet_spg_leaf_consistent":                                   true,
+	"inet_spg_picksplit":                                         true,
+	"inetand":                                                    true,
+	"inetmi":                                                     true,
+	"inetmi_int8":                                                true,
+	"inetnot":                                                    true,
+	"inetor":                                                     true,
+	"inetpl":                                                     true,
+	"initcap":                                                    true,
+	"int2":                                                       true,
+	"int24div":                                                   true,
+	"int24eq":                                                    true,
+	"int24ge":                                                    true,
+	"int24gt":                                                    true,
+	"int24le":                                                    true,
+	"int24lt":                                                    true,
+	"int24mi":                                                    true,
+	"int24mul":                                                   true,
+	"int24ne":                                                    true,
+	"int24pl":                                                    true,
+	"int28div":                                                   true,
+	"int28eq":                                                    true,
+	"int28ge":                                                    true,
+	"int28gt":                                                    true,
+	"int28le":                                                    true,
+	"int28lt":                                                    true,
+	"int28mi":                                                    true,
+	"int28mul":                                                   true,
+	"int28ne":                                                    true,
+	"int28pl":                                                    true,
+	"int2_accum":                                                 true,
+	"int2_accum_inv":                                             true,
+	"int2_avg_accum":                                             true,
+	"int2_avg_accum_inv":                                         true,
+	"int2_mul_cash":                                              true,
+	"int2_numeric":                                               true,
+	"int2_sum":                                                   true,
+	"int2abs":                                                    true,
+	"int2and":                                                    true,
+	"int2div":                                                    true,
+	"int2eq":                                                     true,
+	"int2ge":                                                     true,
+	"int2gt":                                                     true,
+	"int2in":                                                     true,
+	"int2int4_sum":                                               true,
+	"int2larger":                                                 true,
+	"int2le":                                                     true,
+	"int2lt":                                                     true,
+	"int2mi":                                                     true,
+	"int2mod":                                                    true,
+	"int2mul":                                                    true,
+	"int2ne":                                                     true,
+	"int2not":                                                    true,
+	"int2or":                                                     true,
+	"int2out":                                                    true,
+	"int2pl":                                                     true,
+	"int2recv":                                                   true,
+	"int2send":                                                   true,
+	"int2shl":                                                    true,
+	"int2shr":                                                    true,
+	"int2smaller":                                                true,
+	"int2um":                                                     true,
+	"int2up":                                                     true,
+	"int2vectorin":                                               true,
+	"int2vectorout":                                              true,
+	"int2vectorrecv":                                             true,
+	"int2vectorsend":                                             true,
+	"int2xor":                                                    true,
+	"int4":                                                       true,
+	"int42div":                                                   true,
+	"int42eq":                                                    true,
+	"int42ge":                                                    true,
+	"int42gt":                                                    true,
+	"int42le":                                                    true,
+	"int42lt":                                                    true,
+	"int42mi":                                                    true,
+	"int42mul":                                                   true,
+	"int42ne":                                                    true,
+	"int42pl":                                                    true,
+	"int48div":                                                   true,
+	"int48eq":                                                    true,
+	"int48ge":                                                    true,
+	"int48gt":                                                    true,
+	"int48le":                                                    true,
+	"int48lt":                                                    true,
+	"int48mi":                                                    true,
+	"int48mul":                                                   true,
+	"int48ne":                                                    true,
+	"int48pl":                                                    true,
+	"int4_accum":                                                 true,
+	"int4_accum_inv":                                             true,
+	"int4_avg_accum":                                             true,
+	"int4_avg_accum_inv":                                         true,
+	"int4_avg_combine":                                           true,
+	"int4_bool":                                                  true,
+	"int4_mul_cash":                                              true,
+	"int4_numeric":                                               true,
+	"int4_sum":                                                   true,
+	"int4abs":                                                    true,
+	"int4and":                                                    true,
+	"int4div":                                                    true,
+	"int4eq":                                                     true,
+	"int4ge":                                                     true,
+	"int4gt":                                                     true,
+	"int4in":                                                     true,
+	"int4inc":                                                    true,
+	"int4larger":                                                 true,
+	"int4le":                                                     true,
+	"int4lt":                                                     true,
+	"int4mi":                                                     true,
+	"int4mod":                                                    true,
+	"int4mul":                                                    true,
+	"int4multirange":                                             true,
+	"int4ne":                                                     true,
+	"int4not":                                                    true,
+	"int4or":                                                     true,
+	"int4out":                                                    true,
+	"int4pl":                                                     true,
+	"int4range":                                                  true,
+	"int4range_canonical":                                        true,
+	"int4range_int4":                                             true,
+	"int4range_subdiff":                                          true,
+	"int4recv":                                                   true,
+	"int4send":                                                   true,
+	"int4shl":                                                    true,
+	"int4shr":                                                    true,
+	"int4smaller":                                                true,
+	"int4um":                                                     true,
+	"int4up":                                                     true,
+	"int4xor":                                                    true,
+	"int8":                                                       true,
+	"int82div":                                                   true,
+	"int82eq":                                                    true,
+	"int82ge":                                                    true,
+	"int82gt":                                                    true,
+	"int82le":                                                    true,
+	"int82lt":                                                    true,
+	"int82mi":                                                    true,
+	"int82mul":                                                   true,
+	"int82ne":                                                    true,
+	"int82pl":                                                    true,
+	"int84div":                                                   true,
+	"int84eq":                                                    true,
+	"int84ge":                                                    true,
+	"int84gt":                                                    true,
+	"int84le":                                                    true,
+	"int84lt":                                                    true,
+	"int84mi":                                                    true,
+	"int84mul":                                                   true,
+	"int84ne":                                                    true,
+	"int84pl":                                                    true,
+	"int8_accum":                                                 true,
+	"int8_accum_inv":                                             true,
+	"int8_avg":                                                   true,
+	"int8_avg_accum":                                             true,
+	"int8_avg_accum_inv":                                         true,
+	"int8_avg_combine":                                           true,
+	"int8_avg_deserialize":                                       true,
+	"int8_avg_serialize":                                         true,
+	"int8_mul_cash":                                              true,
+	"int8_numeric":                                               true,
+	"int8_sum":                                                   true,
+	"int8abs":                                                    true,
+	"int8and":                                                    true,
+	"int8dec":                                                    true,
+	"int8dec_any":                                                true,
+	"int8div":                                                    true,
+	"int8eq":                                                     true,
+	"int8ge":                                                     true,
+	"int8gt":                                                     true,
+	"int8in":                                                     true,
+	"int8inc":                                                    true,
+	"int8inc_any":                                                true,
+	"int8inc_float8_float8":                                      true,
+	"int8inc_support":                                            true,
+	"int8larger":                                                 true,
+	"int8le":                                                     true,
+	"int8lt":                                                     true,
+	"int8mi":                                                     true,
+	"int8mod":                                                    true,
+	"int8mul":                                                    true,
+	"int8multirange":                                             true,
+	"int8ne":                                                     true,
+	"int8not":                                                    true,
+	"int8or":                                                     true,
+	"int8out":                                                    true,
+	"int8pl":                                                     true,
+	"int8pl_inet":                                                true,
+	"int8range":                                                  true,
+	"int8range_canonical":                                        true,
+	"int8range_int8":                                             true,
+	"int8range_subdiff":                                          true,
+	"int8recv":                                                   true,
+	"int8send":                                                   true,
+	"int8shl":                                                    true,
+	"int8shr":                                                    true,
+	"int8smaller":                                                true,
+	"int8um":                                                     true,
+	"int8up":                                                     true,
+	"int8xor":                                                    true,
+	"integer_pl_date":                                            true,
+	"inter_lb":                                                   true,
+	"inter_sb":                                                   true,
+	"inter_sl":                                                   true,
+	"internal_in":                                                true,
+	"internal_out":                                               true,
+	"interval":                                                   true,
+	"interval_avg":                                               true,
+	"interval_avg_accum":                                         true,
+	"interval_avg_accum_inv":                                     true,
+	"interval_avg_combine":                                       true,
+	"interval_avg_deserialize":                                   true,
+	"interval_avg_serialize":                                     true,
+	"interval_cmp":                                               true,
+	"interval_div":                                               true,
+	"interval_eq":                                                true,
+	"interval_ge":                                                true,
+	"interval_gt":                                                true,
+	"interval_hash":                                              true,
+	"interval_hash_extended":                                     true,
+	"interval_in":                                                true,
+	"interval_larger":                                            true,
+	"interval_le":                                                true,
+	"interval_lt":                                                true,
+	"interval_mi":                                                true,
+	"interval_mul":                                               true,
+	"interval_ne":                                                true,
+	"interval_out":                                               true,
+	"interval_pl":                                                true,
+	"interval_recv":                                              true,
+	"interval_send":                                              true,
+	"interval_smaller":                                           true,
+	"interval_sum":                                               true,
+	"interval_support":                                           true,
+	"interval_time":                                              true,
+	"interval_um":                                                true,
+	"intervaltypmodin":                                           true,
+	"intervaltypmodout":                                          true,
+	"is_normalized":                                              true,
+	"isclosed":                                                   true,
+	"isempty":                                                    true,
+	"isfinite":                                                   true,
+	"ishorizontal":                                               true,
+	"iso8859_1_to_utf8":                                          true,
+	"iso8859_to_utf8":                                            true,
+	"iso_to_koi8r":                                               true,
+	"iso_to_mic":                                                 true,
+	"iso_to_win1251":                                             true,
+	"iso_to_win866":                                              true,
+	"isopen":                                                     true,
+	"isparallel":                                                 true,
+	"isperp":                                                     true,
+	"isvertical":                                                 true,
+	"johab_to_utf8":                                              true,
+	"json_agg":                                                   true,
+	"json_agg_finalfn":                                           true,
+	"json_agg_strict":                                            true,
+	"json_agg_strict_transfn":                                    true,
+	"json_agg_transfn":                                           true,
+	"json_array_element":                                         true,
+	"json_array_element_text":                                    true,
+	"json_array_elements":                                        true,
+	"json_array_elements_text":                                   true,
+	"json_array_length":                                          true,
+	"json_build_array":                                           true,
+	"json_build_object":                                          true,
+	"json_each":                                                  true,
+	"json_each_text":                                             true,
+	"json_extract":                                               true,
+	"json_extract_path":                                          true,
+	"json_extract_path_text":                                     true,
+	"json_in":                                                    true,
+	"json_object":                                                true,
+	"json_object_":                                               true,
+	"json_object_agg":                                            true,
+	"json_object_agg_finalfn":                                    true,
+	"json_object_agg_strict":                                     true,
+	"json_object_agg_strict_transfn":                             true,
+	"json_object_agg_transfn":                                    true,
+	"json_object_agg_unique":                                     true,
+	"json_object_agg_unique_strict":                              true,
+	"json_object_agg_unique_strict_transfn":                      true,
+	"json_object_agg_unique_transfn":                             true,
+	"json_object_field":                                          true,
+	"json_object_field_text":                                     true,
+	"json_object_keys":                                           true,
+	"json_out":                                                   true,
+	"json_populate_record":                                       true,
+	"json_populate_recordset":                                    true,
+	"json_recv":                                                  true,
+	"json_send":                                                  true,
+	"json_strip_nulls":                                           true,
+	"json_to":                                                    true,
+	"json_to_record":                                             true,
+	"json_to_recordset":                                          true,
+	"json_to_tsvector":                                           true,
+	"json_typeof":                                                true,
+	"jsonb_agg":                                                  true,
+	"jsonb_agg_finalfn":                                          true,
+	"jsonb_agg_strict":                                           true,
+	"jsonb_agg_strict_transfn":                                   true,
+	"jsonb_agg_transfn":                                          true,
+	"jsonb_array_element":                                        true,
+	"jsonb_array_element_text":                                   true,
+	"jsonb_array_elements":                                       true,
+	"jsonb_array_elements_text":                                  true,
+	"jsonb_array_length":                                         true,
+	"jsonb_build_array":                                          true,
+	"jsonb_build_object":                                         true,
+	"jsonb_cmp":                                                  true,
+	"jsonb_concat":                                               true,
+	"jsonb_contained":                                            true,
+	"jsonb_contains":                                             true,
+	"jsonb_delete":                                               true,
+	"jsonb_delete_jsonb":                                         true,
+	"jsonb_delete_path":                                          true,
+	"jsonb_each":                                                 true,
+	"jsonb_each_text":                                            true,
+	"jsonb_eq":                                                   true,
+	"jsonb_exists":                                               true,
+	"jsonb_exists_all":                                           true,
+	"jsonb_exists_any":                                           true,
+	"jsonb_extract":                                              true,
+	"jsonb_extract_path":                                         true,
+	"jsonb_extract_path_text":                                    true,
+	"jsonb_ge":                                                   true,
+	"jsonb_gt":                                                   true,
+	"jsonb_hash":                                                 true,
+	"jsonb_hash_extended":                                        true,
+	"jsonb_in":                                                   true,
+	"jsonb_insert":                                               true,
+	"jsonb_le":                                                   true,
+	"jsonb_lt":                                                   true,
+	"jsonb_ne":                                                   true,
+	"jsonb_object":                                               true,
+	"jsonb_object_":                                              true,
+	"jsonb_object_agg":                                           true,
+	"jsonb_object_agg_finalfn":                                   true,
+	"jsonb_object_agg_strict":                                    true,
+	"jsonb_object_agg_strict_transfn":                            true,
+	"jsonb_object_agg_transfn":                                   true,
+	"jsonb_object_agg_unique":                                    true,
+	"jsonb_object_agg_unique_strict":                             true,
+	"jsonb_object_agg_unique_strict_transfn":                     true,
+	"jsonb_object_agg_unique_transfn":                            true,
+	"jsonb_object_field":                                         true,
+	"jsonb_object_field_text":                                    true,
+	"jsonb_object_keys":                                          true,
+	"jsonb_out":                                                  true,
+	"jsonb_path_exists":                                          true,
+	"jsonb_path_exists_opr":                                      true,
+	"jsonb_path_exists_tz":                                       true,
+	"jsonb_path_match":                                           true,
+	"jsonb_path_match_opr":                                       true,
+	"jsonb_path_match_tz":                                        true,
+	"jsonb_path_query":                                           true,
+	"jsonb_path_query_array":                                     true,
+	"jsonb_path_query_array_tz":                                  true,
+	"jsonb_path_query_first":                                     true,
+	"jsonb_path_query_first_tz":                                  true,
+	"jsonb_path_query_tz":                                        true,
+	"jsonb_populate_record":                                      true,
+	"jsonb_populate_record_valid":                                true,
+	"jsonb_populate_recordset":                                   true,
+	"jsonb_pretty":                                               true,
+	"jsonb_recv":                                                 true,
+	"jsonb_send":                                                 true,
+	"jsonb_set":                                                  true,
+	"jsonb_set_lax":                                              true,
+	"jsonb_strip_nulls":                                          true,
+	"jsonb_subscript_handler":                                    true,
+	"jsonb_to":                                                   true,
+	"jsonb_to_record":                                            true,
+	"jsonb_to_recordset":                                         true,
+	"jsonb_to_tsvector":                                          true,
+	"jsonb_typeof":                                               true,
+	"jsonpath_in":                                                true,
+	"jsonpath_out":                                               true,
+	"jsonpath_recv":                                              true,
+	"jsonpath_send":                                              true,
+	"justify_days":                                               true,
+	"justify_hours":                                              true,
+	"justify_interval":                                           true,
+	"koi8r_to_iso":                                               true,
+	"koi8r_to_mic":                                               true,
+	"koi8r_to_utf8":                                              true,
+	"koi8r_to_win1251":                                           true,
+	"koi8r_to_win866":                                            true,
+	"koi8u_to_utf8":                                              true,
+	"lag":                                                        true,
+	"language_handler_in":                                        true,
+	"language_handler_out":                                       true,
+	"last_value":                                                 true,
+	"lastval":                                                    true,
+	"latin1_to_mic":                                              true,
+	"latin2_to_mic":                                              true,
+	"latin2_to_win1250":                                          true,
+	"latin3_to_mic":                                              true,
+	"latin4_to_mic":                                              true,
+	"lcm":                                                        true,
+	"lead":                                                       true,
+	"left":                                                       true,
+	"length":                                                     true,
+	"like":                                                       true,
+	"like_escape":                                                true,
+	"likejoinsel":                                                true,
+	"likesel":                                                    true,
+	"line":                                                       true,
+	"line_distance":                                              true,
+	"line_eq":                                                    true,
+	"line_horizontal":                                            true,
+	"line_in":                                                    true,
+	"line_interpt":                                               true,
+	"line_intersect":                                             true,
+	"line_out":                                                   true,
+	"line_parallel":                                              true,
+	"line_perp":                                                  true,
+	"line_recv":                                                  true,
+	"line_send":                                                  true,
+	"line_vertical":                                              true,
+	"ln":                                                         true,
+	"lo_close":                                                   true,
+	"lo_creat":                                                   true,
+	"lo_create":                                                  true,
+	"lo_export":                                                  true,
+	"lo_from_bytea":                                              true,
+	"lo_get":                                                     true,
+	"lo_import":                                                  true,
+	"lo_lseek":                                                   true,
+	"lo_lseek64":                                                 true,
+	"lo_open":                                                    true,
+	"lo_put":                                                     true,
+	"lo_tell":                                                    true,
+	"lo_tell64":                                                  true,
+	"lo_truncate":                                                true,
+	"lo_truncate64":                                              true,
+	"lo_unlink":                                                  true,
+	"log":                                                        true,
+	"log10":                                                      true,
+	"loread":                                                     true,
+	"lower":                                                      true,
+	"lower_inc":                                                  true,
+	"lower_inf":                                                  true,
+	"lowrite":                                                    true,
+	"lpad":                                                       true,
+	"lpad_text":                                                  true,
+	"lseg":                                                       true,
+	"lseg_center":                                                true,
+	"lseg_distance":                                              true,
+	"lseg_eq":                                                    true,
+	"lseg_ge":                                                    true,
+	"lseg_gt":                                                    true,
+	"lseg_horizontal":                                            true,
+	"lseg_in":                                                    true,
+	"lseg_interpt":                                               true,
+	"lseg_intersect":                                             true,
+	"lseg_le":                                                    true,
+	"lseg_length":                                                true,
+	"lseg_lt":                                                    true,
+	"lseg_ne":                                                    true,
+	"lseg_out":                                                   true,
+	"lseg_parallel":                                              true,
+	"lseg_perp":                                                  true,
+	"lseg_recv":                                                  true,
+	"lseg_send":                                                  true,
+	"lseg_vertical":                                              true,
+	"ltrim":                                                      true,
+	"macaddr":                                                    true,
+	"macaddr8":                                                   true,
+	"macaddr8_and":                                               true,
+	"macaddr8_cmp":                                               true,
+	"macaddr8_eq":                                                true,
+	"macaddr8_ge":                                                true,
+	"macaddr8_gt":                                                true,
+	"macaddr8_in":                                                true,
+	"macaddr8_le":                                                true,
+	"macaddr8_lt":                                                true,
+	"macaddr8_ne":                                                true,
+	"macaddr8_not":                                               true,
+	"macaddr8_or":                                                true,
+	"macaddr8_out":                                               true,
+	"macaddr8_recv":                                              true,
+	"macaddr8_send":                                              true,
+	"macaddr8_set7bit":                                           true,
+	"macaddr_and":                                                true,
+	"macaddr_cmp":                                                true,
+	"macaddr_eq":                                                 true,
+	"macaddr_ge":                                                 true,
+	"macaddr_gt":                                                 true,
+	"macaddr_in":                                                 true,
+	"macaddr_le":                                                 true,
+	"macaddr_lt":                                                 true,
+	"macaddr_ne":                                                 true,
+	"macaddr_not":                                                true,
+	"macaddr_or":                                                 true,
+	"macaddr_out":                                                true,
+	"macaddr_recv":                                               true,
+	"macaddr_send":                                               true,
+	"macaddr_sortsupport":                                        true,
+	"make":                                                       true,
+	"make_date":                                                  true,
+	"make_interval":                                              true,
+	"make_time":                                                  true,
+	"make_timestamp":                                             true,
+	"make_timestamptz":                                           true,
+	"make_timestamptz_int4":                                      true,
+	"make_timestamptz_int4_int4":                                 true,
+	"make_timestamptz_int4_int4_int4":                            true,
+	"make_timestamptz_int4_int4_int4_int4":                       true,
+	"make_timestamptz_int4_int4_int4_int4_int4": true,
+	"makeaclitem":                            true,
+	"masklen":                                true,
+	"matchingjoinsel":                        true,
+	"matchingsel":                            true,
+	"max":                                    true,
+	"md5":                                    true,
+	"md5_bytea":                              true,
+	"md5_text":                               true,
+	"mic_to_big5":                            true,
+	"mic_to_euc_cn":                          true,
+	"mic_to_euc_jp":                          true,
+	"mic_to_euc_kr":                          true,
+	"mic_to_euc_tw":                          true,
+	"mic_to_iso":                             true,
+	"mic_to_koi8r":                           true,
+	"mic_to_latin1":                          true,
+	"mic_to_latin2":                          true,
+	"mic_to_latin3":                          true,
+	"mic_to_latin4":                          true,
+	"mic_to_sjis":                            true,
+	"mic_to_win1250":                         true,
+	"mic_to_win1251":                         true,
+	"mic_to_win866":                          true,
+	"min":                                    true,
+	"min_scale":                              true,
+	"mod":                                    true,
+	"mode":                                   true,
+	"mode_final":                             true,
+	"money":                                  true,
+	"mul_d_interval":                         true,
+	"multirange":                             true,
+	"multirange_adjacent_multirange":         true,
+	"multirange_adjacent_range":              true,
+	"multirange_after_multirange":            true,
+	"multirange_after_range":                 true,
+	"multirange_agg_finalfn":                 true,
+	"multirange_agg_transfn":                 true,
+	"multirange_before_multirange":           true,
+	"multirange_before_range":                true,
+	"multirange_cmp":                         true,
+	"multirange_contained_by_multirange":     true,
+	"multirange_contained_by_range":          true,
+	"multirange_contains_elem":               true,
+	"multirange_contains_multirange":         true,
+	"multirange_contains_range":              true,
+	"multirange_eq":                          true,
+	"multirange_ge":                          true,
+	"multirange_gist_compress":               true,
+	"multirange_gist_consistent":             true,
+	"multirange_gt":                          true,
+	"multirange_in":                          true,
+	"multirange_intersect":                   true,
+	"multirange_intersect_agg_transfn":       true,
+	"multirange_le":                          true,
+	"multirange_lt":                          true,
+	"multirange_minus":                       true,
+	"multirange_ne":                          true,
+	"multirange_out":                         true,
+	"multirange_overlaps_multirange":         true,
+	"multirange_overlaps_range":              true,
+	"multirange_overleft_multirange":         true,
+	"multirange_overleft_range":              true,
+	"multirange_overright_multirange":        true,
+	"multirange_overright_range":             true,
+	"multirange_recv":                        true,
+	"multirange_send":                        true,
+	"multirange_typanalyze":                  true,
+	"multirange_union":                       true,
+	"multirangesel":                          true,
+	"mxid_age":                               true,
+	"name":                                   true,
+	"name_bpchar":                            true,
+	"name_text":                              true,
+	"nameconcatoid":                          true,
+	"nameeq":                                 true,
+	"nameeqtext":                             true,
+	"namege":                                 true,
+	"namegetext":                             true,
+	"namegt":                                 true,
+	"namegttext":                             true,
+	"nameiclike":                             true,
+	"nameicnlike":                            true,
+	"nameicregexeq":                          true,
+	"nameicregexne":                          true,
+	"namein":                                 true,
+	"namele":                                 true,
+	"nameletext":                             true,
+	"namelike":                               true,
+	"namelt":                                 true,
+	"namelttext":                             true,
+	"namene":                                 true,
+	"namenetext":                             true,
+	"namenlike":                              true,
+	"nameout":                                true,
+	"namerecv":                               true,
+	"nameregexeq":                            true,
+	"nameregexne":                            true,
+	"namesend":                               true,
+	"neqjoinsel":                             true,
+	"neqsel":                                 true,
+	"netmask":                                true,
+	"network":                                true,
+	"network_cmp":                            true,
+	"network_eq":                             true,
+	"network_ge":                             true,
+	"network_gt":                             true,
+	"network_larger":                         true,
+	"network_le":                             true,
+	"network_lt":                             true,
+	"network_ne":                             true,
+	"network_overlap":                        true,
+	"network_smaller":                        true,
+	"network_sortsupport":                    true,
+	"network_sub":                            true,
+	"network_subeq":                          true,
+	"network_subset_support":                 true,
+	"network_sup":                            true,
+	"network_supeq":                          true,
+	"networkjoinsel":                         true,
+	"networksel":                             true,
+	"nextval":                                true,
+	"nlikejoinsel":                           true,
+	"nlikesel":                               true,
+	"normalize":                              true,
+	"notlike":                                true,
+	"now":                                    true,
+	"npoints":                                true,
+	"nth_value":                              true,
+	"ntile":                                  true,
+	"num_nonnulls":                           true,
+	"num_nulls":                              true,
+	"numeric":                                true,
+	"numeric_abs":                            true,
+	"numeric_accum":                          true,
+	"numeric_accum_inv":                      true,
+	"numeric_add":                            true,
+	"numeric_avg":                            true,
+	"numeric_avg_accum":                      true,
+	"numeric_avg_combine":                    true,
+	"numeric_avg_deserialize":                true,
+	"numeric_avg_serialize":                  true,
+	"numeric_cmp":                            true,
+	"numeric_combine":                        true,
+	"numeric_deserialize":                    true,
+	"numeric_div":                            true,
+	"numeric_div_trunc":                      true,
+	"numeric_eq":                             true,
+	"numeric_exp":                            true,
+	"numeric_float4":                         true,
+	"numeric_float8":                         true,
+	"numeric_ge":                             true,
+	"numeric_gt":                             true,
+	"numeric_in":                             true,
+	"numeric_inc":                            true,
+	"numeric_int2":                           true,
+	"numeric_int4":                           true,
+	"numeric_int8":                           true,
+	"numeric_larger":                         true,
+	"numeric_le":                             true,
+	"numeric_ln":                             true,
+	"numeric_log":                            true,
+	"numeric_lt":                             true,
+	"numeric_mod":                            true,
+	"numeric_mul":                            true,
+	"numeric_ne":                             true,
+	"numeric_out":                            true,
+	"numeric_pl_pg_lsn":                      true,
+	"numeric_poly_avg":                       true,
+	"numeric_poly_combine":                   true,
+	"numeric_poly_deserialize":               true,
+	"numeric_poly_serialize":                 true,
+	"numeric_poly_stddev_pop":                true,
+	"numeric_poly_stddev_samp":               true,
+	"numeric_poly_sum":                       true,
+	"numeric_poly_var_pop":                   true,
+	"numeric_poly_var_samp":                  true,
+	"numeric_power":                          true,
+	"numeric_recv":                           true,
+	"numeric_send":                           true,
+	"numeric_serialize":                      true,
+	"numeric_smaller":                        true,
+	"numeric_sortsupport":                    true,
+	"numeric_sqrt":                           true,
+	"numeric_stddev_pop":                     true,
+	"numeric_stddev_samp":                    true,
+	"numeric_sub":                            true,
+	"numeric_sum":                            true,
+	"numeric_support":                        true,
+	"numeric_uminus":                         true,
+	"numeric_uplus":                          true,
+	"numeric_var_pop":                        true,
+	"numeric_var_samp":                       true,
+	"numerictypmodin":                        true,
+	"numerictypmodout":                       true,
+	"nummultirange":                          true,
+	"numnode":                                true,
+	"numrange":                               true,
+	"numrange_numeric":                       true,
+	"numrange_subdiff":                       true,
+	"obj_description":                        true,
+	"octet_length":                           true,
+	"oid":                                    true,
+	"oideq":                                  true,
+	"oidge":                                  true,
+	"oidgt":                                  true,
+	"oidin":                                  true,
+	"oidlarger":                              true,
+	"oidle":                                  true,
+	"oidlt":                                  true,
+	"oidne":                                  true,
+	"oidout":                                 true,
+	"oidrecv":                                true,
+	"oidsend":                                true,
+	"oidsmaller":                             true,
+	"oidvectoreq":                            true,
+	"oidvectorge":                            true,
+	"oidvectorgt":                            true,
+	"oidvectorin":                            true,
+	"oidvectorle":                            true,
+	"oidvectorlt":                            true,
+	"oidvectorne":                            true,
+	"oidvectorout":                           true,
+	"oidvectorrecv":                          true,
+	"oidvectorsend":                          true,
+	"oidvectortypes":                         true,
+	"on_pb":                                  true,
+	"on_pl":                                  true,
+	"on_ppath":                               true,
+	"on_ps":                                  true,
+	"on_sb":                                  true,
+	"on_sl":                                  true,
+	"ordered_set_transition":                 true,
+	"ordered_set_transition_multi":           true,
+	"overlaps":                               true,
+	"overlaps_time":                          true,
+	"overlaps_time_interval":                 true,
+	"overlaps_time_interval_time":            true,
+	"overlaps_time_time":                     true,
+	"overlaps_time_time_time":                true,
+	"overlaps_timestamp":                     true,
+	"overlaps_timestamp_interval":            true,
+	"overlaps_timestamp_interval_timestamp":  true,
+	"overlaps_timestamp_timestamp":           true,
+	"overlaps_timestamp_timestamp_timestamp": true,
+	"overlaps_timestamptz":                   true,
+	"overlaps_timestamptz_interval":          true,
+	"overlaps_timestamptz_interval_timestamptz":    true,
+	"overlaps_timestamptz_timestamptz":             true,
+	"overlaps_timestamptz_timestamptz_timestamptz": true,
+	"overlay":                                          true,
+	"overlay_bit":                                      true,
+	"overlay_bit_bit":                                  true,
+	"overlay_bytea":                                    true,
+	"overlay_bytea_bytea":                              true,
+	"overlay_text":                                     true,
+	"overlay_text_text":                                true,
+	"parse_ident":                                      true,
+	"path":                                             true,
+	"path_add":                                         true,
+	"path_add_pt":                                      true,
+	"path_contain_pt":                                  true,
+	"path_distance":                                    true,
+	"path_div_pt":                                      true,
+	"path_in":                                          true,
+	"path_inter":                                       true,
+	"path_length":                                      true,
+	"path_mul_pt":                                      true,
+	"path_n_eq":                                        true,
+	"path_n_ge":                                        true,
+	"path_n_gt":                                        true,
+	"path_n_le":                                        true,
+	"path_n_lt":                                        true,
+	"path_npoints":                                     true,
+	"path_out":                                         true,
+	"path_recv":                                        true,
+	"path_send":                                        true,
+	"path_sub_pt":                                      true,
+	"pclose":                                           true,
+	"percent_rank":                                     true,
+	"percent_rank_final":                               true,
+	"percentile_cont":                                  true,
+	"percentile_cont_":                                 true,
+	"percentile_cont__float8":                          true,
+	"percentile_cont_float8":                           true,
+	"percentile_cont_float8_final":                     true,
+	"percentile_cont_float8_multi_final":               true,
+	"percentile_cont_interval_final":                   true,
+	"percentile_cont_interval_multi_final":             true,
+	"percentile_disc":                                  true,
+	"percentile_disc_final":                            true,
+	"percentile_disc_multi_final":                      true,
+	"pg_advisory_lock":                                 true,
+	"pg_advisory_lock_int8":                            true,
+	"pg_advisory_lock_shared":                          true,
+	"pg_advisory_lock_shared_int8":                     true,
+	"pg_advisory_unlock":                               true,
+	"pg_advisory_unlock_all":                           true,
+	"pg_advisory_unlock_int8":                          true,
+	"pg_advisory_unlock_shared":                        true,
+	"pg_advisory_unlock_shared_int8":                   true,
+	"pg_advisory_xact_lock":                            true,
+	"pg_advisory_xact_lock_int8":                       true,
+	"pg_advisory_xact_lock_shared":                     true,
+	"pg_advisory_xact_lock_shared_int8":                true,
+	"pg_available_extension_versions":                  true,
+	"pg_available_extensions":                          true,
+	"pg_available_wal_summaries":                       true,
+	"pg_backend_pid":                                   true,
+	"pg_backup_start":                                  true,
+	"pg_backup_stop":                                   true,
+	"pg_basetype":                                      true,
+	"pg_blocking_pids":                                 true,
+	"pg_cancel_backend":                                true,
+	"pg_char_to_encoding":                              true,
+	"pg_client_encoding":                               true,
+	"pg_collation_actual_version":                      true,
+	"pg_collation_for":                                 true,
+	"pg_collation_is_visible":                          true,
+	"pg_column_compression":                            true,
+	"pg_column_is_updatable":                           true,
+	"pg_column_size":                                   true,
+	"pg_column_toast_chunk_id":                         true,
+	"pg_conf_load_time":                                true,
+	"pg_config":                                        true,
+	"pg_control_checkpoint":                            true,
+	"pg_control_init":                                  true,
+	"pg_control_recovery":                              true,
+	"pg_control_system":                                true,
+	"pg_conversion_is_visible":                         true,
+	"pg_copy_logical_replication_slot":                 true,
+	"pg_copy_logical_replication_slot_name":            true,
+	"pg_copy_logical_replication_slot_name_name":       true,
+	"pg_copy_physical_replication_slot":                true,
+	"pg_copy_physical_replication_slot_name":           true,
+	"pg_create_logical_replication_slot":               true,
+	"pg_create_physical_replication_slot":              true,
+	"pg_create_restore_point":                          true,
+	"pg_current_logfile":                               true,
+	"pg_current_snapshot":                              true,
+	"pg_current_wal_flush_lsn":                         true,
+	"pg_current_wal_insert_lsn":                        true,
+	"pg_current_wal_lsn":                               true,
+	"pg_current_xact_id":                               true,
+	"pg_current_xact_id_if_assigned":                   true,
+	"pg_cursor":                                        true,
+	"pg_database_collation_actual_version":             true,
+	"pg_database_size":                                 true,
+	"pg_database_size_name":                            true,
+	"pg_database_size_oid":                             true,
+	"pg_ddl_command_in":                                true,
+	"pg_ddl_command_out":                               true,
+	"pg_ddl_command_recv":                              true,
+	"pg_ddl_command_send":                              true,
+	"pg_dependencies_in":                               true,
+	"pg_dependencies_out":                              true,
+	"pg_dependencies_recv":                             true,
+	"pg_dependencies_send":                             true,
+	"pg_describe_object":                               true,
+	"pg_drop_replication_slot":                         true,
+	"pg_encoding_max_length":                           true,
+	"pg_encoding_to_char":                              true,
+	"pg_event_trigger_ddl_commands":                    true,
+	"pg_event_trigger_dropped_objects":                 true,
+	"pg_event_trigger_table_rewrite_oid":               true,
+	"pg_event_trigger_table_rewrite_reason":            true,
+	"pg_export_snapshot":                               true,
+	"pg_extension_config_dump":                         true,
+	"pg_extension_update_paths":                        true,
+	"pg_filenode_relation":                             true,
+	"pg_function_is_visible":                           true,
+	"pg_get_backend_memory_contexts":                   true,
+	"pg_get_catalog_foreign_keys":                      true,
+	"pg_get_constraintdef":                             true,
+	"pg_get_expr":                                      true,
+	"pg_get_expr_pg_node_tree":                         true,
+	"pg_get_function_arg_default":                      true,
+	"pg_get_function_arguments":                        true,
+	"pg_get_function_identity_arguments":               true,
+	"pg_get_function_result":                           true,
+	"pg_get_function_sqlbody":                          true,
+	"pg_get_functiondef":                               true,
+	"pg_get_indexdef":                                  true,
+	"pg_get_keywords":                                  true,
+	"pg_get_multixact_members":                         true,
+	"pg_get_object_address":                            true,
+	"pg_get_partition_constraintdef":                   true,
+	"pg_get_partkeydef":                                true,
+	"pg_get_publication_tables":                        true,
+	"pg_get_replica_identity_index":                    true,
+	"pg_get_replication_slots":                         true,
+	"pg_get_ruledef":                                   true,
+	"pg_get_serial_sequence":                           true,
+	"pg_get_shmem_allocations":                         true,
+	"pg_get_statisticsobjdef":                          true,
+	"pg_get_statisticsobjdef_columns":                  true,
+	"pg_get_statisticsobjdef_expressions":              true,
+	"pg_get_triggerdef":                                true,
+	"pg_get_userbyid":                                  true,
+	"pg_get_viewdef":                                   true,
+	"pg_get_viewdef_oid":                               true,
+	"pg_get_wait_events":                               true,
+	"pg_get_wal_replay_pause_state":                    true,
+	"pg_get_wal_resource_managers":                     true,
+	"pg_get_wal_summarizer_state":                      true,
+	"pg_has_role":                                      true,
+	"pg_has_role_name":                                 true,
+	"pg_has_role_oid":                                  true,
+	"pg_hba_file_rules":                                true,
+	"pg_ident_file_mappings":                           true,
+	"pg_identify_object":                               true,
+	"pg_identify_object_as_address":                    true,
+	"pg_import_system_collations":                      true,
+	"pg_index_column_has_property":                     true,
+	"pg_index_has_property":                            true,
+	"pg_indexam_has_property":                          true,
+	"pg_indexam_progress_phasename":                    true,
+	"pg_indexes_size":                                  true,
+	"pg_input_error_info":                              true,
+	"pg_input_is_valid":                                true,
+	"pg_is_in_recovery":                                true,
+	"pg_is_other_temp_schema":                          true,
+	"pg_is_wal_replay_paused":                          true,
+	"pg_isolation_test_session_is_blocked":             true,
+	"pg_jit_available":                                 true,
+	"pg_last_committed_xact":                           true,
+	"pg_last_wal_receive_lsn":                          true,
+	"pg_last_wal_replay_lsn":                           true,
+	"pg_last_xact_replay_timestamp":                    true,
+	"pg_listening_channels":                            true,
+	"pg_lock_status":                                   true,
+	"pg_log_backend_memory_contexts":                   true,
+	"pg_log_standby_snapshot":                          true,
+	"pg_logical_emit_message":                          true,
+	"pg_logical_emit_message_bool":                     true,
+	"pg_logical_emit_message_bool_text":                true,
+	"pg_logical_slot_get_binary_changes":               true,
+	"pg_logical_slot_get_changes":                      true,
+	"pg_logical_slot_peek_binary_changes":              true,
+	"pg_logical_slot_peek_changes":                     true,
+	"pg_ls_archive_statusdir":                          true,
+	"pg_ls_dir":                                        true,
+	"pg_ls_logdir":                                     true,
+	"pg_ls_logicalmapdir":                              true,
+	"pg_ls_logicalsnapdir":                             true,
+	"pg_ls_replslotdir":                                true,
+	"pg_ls_tmpdir":                                     true,
+	"pg_ls_waldir":                                     true,
+	"pg_lsn":                                           true,
+	"pg_lsn_cmp":                                       true,
+	"pg_lsn_eq":                                        true,
+	"pg_lsn_ge":                                        true,
+	"pg_lsn_gt":                                        true,
+	"pg_lsn_hash":                                      true,
+	"pg_lsn_hash_extended":                             true,
+	"pg_lsn_in":                                        true,
+	"pg_lsn_larger":                                    true,
+	"pg_lsn_le":                                        true,
+	"pg_lsn_lt":                                        true,
+	"pg_lsn_mi":                                        true,
+	"pg_lsn_mii":                                       true,
+	"pg_lsn_ne":                                        true,
+	"pg_lsn_out":                                       true,
+	"pg_lsn_pli":                                       true,
+	"pg_lsn_recv":                                      true,
+	"pg_lsn_send":                                      true,
+	"pg_lsn_smaller":                                   true,
+	"pg_mcv_list_in":                                   true,
+	"pg_mcv_list_items":                                true,
+	"pg_mcv_list_out":                                  true,
+	"pg_mcv_list_recv":                                 true,
+	"pg_mcv_list_send":                                 true,
+	"pg_my_temp_schema":                                true,
+	"pg_ndistinct_in":                                  true,
+	"pg_ndistinct_out":                                 true,
+	"pg_ndistinct_recv":                                true,
+	"pg_ndistinct_send":                                true,
+	"pg_nextoid":                                       true,
+	"pg_node_tree_in":                                  true,
+	"pg_node_tree_out":                                 true,
+	"pg_node_tree_recv":                                true,
+	"pg_node_tree_send":                                true,
+	"pg_notification_queue_usage":                      true,
+	"pg_notify":                                        true,
+	"pg_opclass_is_visible":                            true,
+	"pg_operator_is_visible":                           true,
+	"pg_opfamily_is_visible":                           true,
+	"pg_options_to_table":                              true,
+	"pg_partition_ancestors":                           true,
+	"pg_partition_root":                                true,
+	"pg_partition_tree":                                true,
+	"pg_postmaster_start_time":                         true,
+	"pg_prepared_statement":                            true,
+	"pg_prepared_xact":                                 true,
+	"pg_promote":                                       true,
+	"pg_read_binary_file":                              true,
+	"pg_read_binary_file_text":                         true,
+	"pg_read_binary_file_text_int8":                    true,
+	"pg_read_file":                                     true,
+	"pg_read_file_text":                                true,
+	"pg_read_file_text_int8":                           true,
+	"pg_relation_filenode":                             true,
+	"pg_relation_filepath":                             true,
+	"pg_relation_is_publishable":                       true,
+	"pg_relation_is_updatable":                         true,
+	"pg_relation_size":                                 true,
+	"pg_reload_conf":                                   true,
+	"pg_replication_origin_advance":                    true,
+	"pg_replication_origin_create":                     true,
+	"pg_replication_origin_drop":                       true,
+	"pg_replication_origin_oid":                        true,
+	"pg_replication_origin_progress":                   true,
+	"pg_replication_origin_session_is_setup":           true,
+	"pg_replication_origin_session_progress":           true,
+	"pg_replication_origin_session_reset":              true,
+	"pg_replication_origin_session_setup":              true,
+	"pg_replication_origin_xact_reset":                 true,
+	"pg_replication_origin_xact_setup":                 true,
+	"pg_replication_slot_advance":                      true,
+	"pg_rotate_logfile":                                true,
+	"pg_safe_snapshot_blocking_pids":                   true,
+	"pg_sequence_last_value":                           true,
+	"pg_sequence_parameters":                           true,
+	"pg_settings_get_flags":                            true,
+	"pg_show_all_file_settings":                        true,
+	"pg_show_all_settings":                             true,
+	"pg_show_replication_origin_status":                true,
+	"pg_size_bytes":                                    true,
+	"pg_size_pretty":                                   true,
+	"pg_size_pretty_numeric":                           true,
+	"pg_sleep":                                         true,
+	"pg_sleep_for":                                     true,
+	"pg_sleep_until":                                   true,
+	"pg_snapshot_in":                                   true,
+	"pg_snapshot_out":                                  true,
+	"pg_snapshot_recv":                                 true,
+	"pg_snapshot_send":                                 true,
+	"pg_snapshot_xip":                                  true,
+	"pg_snapshot_xmax":                                 true,
+	"pg_snapshot_xmin":                                 true,
+	"pg_split_walfile_name":                            true,
+	"pg_stat_clear_snapshot":                           true,
+	"pg_stat_file":                                     true,
+	"pg_stat_force_next_flush":                         true,
+	"pg_stat_get_activity":                             true,
+	"pg_stat_get_analyze_count":                        true,
+	"pg_stat_get_archiver":                             true,
+	"pg_stat_get_autoanalyze_count":                    true,
+	"pg_stat_get_autovacuum_count":                     true,
+	"pg_stat_get_backend_activity":                     true,
+	"pg_stat_get_backend_activity_start":               true,
+	"pg_stat_get_backend_client_addr":                  true,
+	"pg_stat_get_backend_client_port":                  true,
+	"pg_stat_get_backend_dbid":                         true,
+	"pg_stat_get_backend_idset":                        true,
+	"pg_stat_get_backend_pid":                          true,
+	"pg_stat_get_backend_start":                        true,
+	"pg_stat_get_backend_subxact":                      true,
+	"pg_stat_get_backend_userid":                       true,
+	"pg_stat_get_backend_wait_event":                   true,
+	"pg_stat_get_backend_wait_event_type":              true,
+	"pg_stat_get_backend_xact_start":                   true,
+	"pg_stat_get_bgwriter_buf_written_clean":           true,
+	"pg_stat_get_bgwriter_maxwritten_clean":            true,
+	"pg_stat_get_bgwriter_stat_reset_time":             true,
+	"pg_stat_get_blocks_fetched":                       true,
+	"pg_stat_get_blocks_hit":                           true,
+	"pg_stat_get_buf_alloc":                            true,
+	"pg_stat_get_checkpointer_buffers_written":         true,
+	"pg_stat_get_checkpointer_num_requested":           true,
+	"pg_stat_get_checkpointer_num_timed":               true,
+	"pg_stat_get_checkpointer_restartpoints_performed": true,
+	"pg_stat_get_checkpointer_restartpoints_requested": true,
+	"pg_stat_get_checkpointer_restartpoints_timed":     true,
+	"pg_stat_get_checkpointer_stat_reset_time":         true,
+	"pg_stat_get_checkpointer_sync_time":               true,
+	"pg_stat_get_checkpointer_write_time":              true,
+	"pg_stat_get_db_active_time":                       true,
+	"pg_stat_get_db_blk_read_time":                     true,
+	"pg_stat_get_db_blk_write_time":                    true,
+	"pg_stat_get_db_blocks_fetched":                    true,
+	"pg_stat_get_db_blocks_hit":                        true,
+	"pg_stat_get_db_checksum_failures":                 true,
+	"pg_stat_get_db_checksum_last_failure":             true,
+	"pg_stat_get_db_conflict_all":                      true,
+	"pg_stat_get_db_conflict_bufferpin":                true,
+	"pg_stat_get_db_conflict_lock":                     true,
+	"pg_stat_get_db_conflict_logicalslot":              true,
+	"pg_stat_get_db_conflict_snapshot":                 true,
+	"pg_stat_get_db_conflict_startup_deadlock":         true,
+	"pg_stat_get_db_conflict_tablespace":               true,
+	"pg_stat_get_db_deadlocks":                         true,
+	"pg_stat_get_db_idle_in_transaction_time":          true,
+	"pg_stat_get_db_numbackends":                       true,
+	"pg_stat_get_db_session_time":                      true,
+	"pg_stat_get_db_sessions":                          true,
+	"pg_stat_get_db_sessions_abandoned":                true,
+	"pg_stat_get_db_sessions_fatal":                    true,
+	"pg_stat_get_db_sessions_killed":                   true,
+	"pg_stat_get_db_stat_reset_time":                   true,
+	"pg_stat_get_db_temp_bytes":                        true,
+	"pg_stat_get_db_temp_files":                        true,
+	"pg_stat_get_db_tuples_deleted":                    true,
+	"pg_stat_get_db_tuples_fetched":                    true,
+	"pg_stat_get_db_tuples_inserted":                   true,
+	"pg_stat_get_db_tuples_returned":                   true,
+	"pg_stat_get_db_tuples_updated":                    true,
+	"pg_stat_get_db_xact_commit":                       true,
+	"pg_stat_get_db_xact_rollback":                     true,
+	"pg_stat_get_dead_tuples":                          true,
+	"pg_stat_get_function_calls":                       true,
+	"pg_stat_get_function_self_time":                   true,
+	"pg_stat_get_function_total_time":                  true,
+	"pg_stat_get_ins_since_vacuum":                     true,
+	"pg_stat_get_io":                                   true,
+	"pg_stat_get_last_analyze_time":                    true,
+	"pg_stat_get_last_autoanalyze_time":                true,
+	"pg_stat_get_last_autovacuum_time":                 true,
+	"pg_stat_get_last_vacuum_time":                     true,
+	"pg_stat_get_lastscan":                             true,
+	"pg_stat_get_live_tuples":                          true,
+	"pg_stat_get_mod_since_analyze":                    true,
+	"pg_stat_get_numscans":                             true,
+	"pg_stat_get_progress_info":                        true,
+	"pg_stat_get_recovery_prefetch":                    true,
+	"pg_stat_get_replication_slot":                     true,
+	"pg_stat_get_slru":                                 true,
+	"pg_stat_get_snapshot_timestamp":                   true,
+	"pg_stat_get_subscription":                         true,
+	"pg_stat_get_subscription_stats":                   true,
+	"pg_stat_get_tuples_deleted":                       true,
+	"pg_stat_get_tuples_fetched":                       true,
+	"pg_stat_get_tuples_hot_updated":                   true,
+	"pg_stat_get_tuples_inserted":                      true,
+	"pg_stat_get_tuples_newpage_updated":               true,
+	"pg_stat_get_tuples_returned":                      true,
+	"pg_stat_get_tuples_updated":                       true,
+	"pg_stat_get_vacuum_count":                         true,
+	"pg_stat_get_wal":                                  true,
+	"pg_stat_get_wal_receiver":                         true,
+	"pg_stat_get_wal_senders":                          true,
+	"pg_stat_get_xact_blocks_fetched":                  true,
+	"pg_stat_get_xact_blocks_hit":                      true,
+	"pg_stat_get_xact_function_calls":                  true,
+	"pg_stat_get_xact_function_self_time":              true,
+	"pg_stat_get_xact_function_total_time":             true,
+	"pg_stat_get_xact_numscans":                        true,
+	"pg_stat_get_xact_tuples_deleted":                  true,
+	"pg_stat_get_xact_tuples_fetched":                  true,
+	"pg_stat_get_xact_tuples_hot_updated":              true,
+	"pg_stat_get_xact_tuples_inserted":                 true,
+	"pg_stat_get_xact_tuples_newpage_updated":          true,
+	"pg_stat_get_xact_tuples_returned":                 true,
+	"pg_stat_get_xact_tuples_updated":                  true,
+	"pg_stat_have_stats":                               true,
+	"pg_stat_reset":                                    true,
+	"pg_stat_reset_replication_slot":                   true,
+	"pg_stat_reset_shared":                             true,
+	"pg_stat_reset_single_function_counters":           true,
+	"pg_stat_reset_single_table_counters":              true,
+	"pg_stat_reset_slru":                               true,
+	"pg_stat_reset_subscription_stats":                 true,
+	"pg_statistics_obj_is_visible":                     true,
+	"pg_stop_making_pinned_objects":                    true,
+	"pg_switch_wal":                                    true,
+	"pg_sync_replication_slots":                        true,
+	"pg_table_is_visible":                              true,
+	"pg_table_size":                                    true,
+	"pg_tablespace_databases":                          true,
+	"pg_tablespace_location":                           true,
+	"pg_tablespace_size":                               true,
+	"pg_tablespace_size_name":                          true,
+	"pg_tablespace_size_oid":                           true,
+	"pg_terminate_backend":                             true,
+	"pg_timezone_abbrevs":                              true,
+	"pg_timezone_names":                                true,
+	"pg_total_relation_size":                           true,
+	"pg_trigger_depth":                                 true,
+	"pg_try_advisory_lock":                             true,
+	"pg_try_advisory_lock_int8":                        true,
+	"pg_try_advisory_lock_shared":                      true,
+	"pg_try_advisory_lock_shared_int8":                 true,
+	"pg_try_advisory_xact_lock":                        true,
+	"pg_try_advisory_xact_lock_int8":                   true,
+	"pg_try_advisory_xact_lock_shared":                 true,
+	"pg_try_advisory_xact_lock_shared_int8":            true,
+	"pg_ts_config_is_visible":                          true,
+	"pg_ts_dict_is_visible":                            true,
+	"pg_ts_parser_is_visible":                          true,
+	"pg_ts_template_is_visible":                        true,
+	"pg_type_is_visible":                               true,
+	"pg_typeof":                                        true,
+	"pg_visible_in_snapshot":                           true,
+	"pg_wal_lsn_diff":                                  true,
+	"pg_wal_replay_pause":                              true,
+	"pg_wal_replay_resume":                             true,
+	"pg_wal_summary_contents":                          true,
+	"pg_walfile_name":                                  true,
+	"pg_walfile_name_offset":                           true,
+	"pg_xact_commit_timestamp":                         true,
+	"pg_xact_commit_timestamp_origin":                  true,
+	"pg_xact_status":                                   true,
+	"phraseto":                                         true,
+	"phraseto_tsquery":                                 true,
+	"pi":                                               true,
+	"plainto":                                          true,
+	"plainto_tsquery":                                  true,
+	"point":                                            true,
+	"point_above":                                      true,
+	"point_add":                                        true,
+	"point_below":                                      true,
+	"point_box":                                        true,
+	"point_distance":                                   true,
+	"point_div":                                        true,
+	"point_eq":                                         true,
+	"point_horiz":                                      true,
+	"point_in":                                         true,
+	"point_left":                                       true,
+	"point_mul":                                        true,
+	"point_ne":                                         true,
+	"point_out":                                        true,
+	"point_recv":                                       true,
+	"point_right":                                      true,
+	"point_send":                                       true,
+	"point_sub":                                        true,
+	"point_vert":                                       true,
+	"poly_above":                                       true,
+	"poly_below":                                       true,
+	"poly_center":                                      true,
+	"poly_contain":                                     true,
+	"poly_contain_pt":                                  true,
+	"poly_contained":                                   true,
+	"poly_distance":                                    true,
+	"poly_in":                                          true,
+	"poly_left":                                        true,
+	"poly_npoints":                                     true,
+	"poly_out":                                         true,
+	"poly_overabove":                                   true,
+	"poly_overbelow":                                   true,
+	"poly_overlap":                                     true,
+	"poly_overleft":                                    true,
+	"poly_overright":                                   true,
+	"poly_recv":                                        true,
+	"poly_right":                                       true,
+	"poly_same":                                        true,
+	"poly_send":                                        true,
+	"polygon":                                          true,
+	"popen":                                            true,
+	"position":                                         true,
+	"positionjoinsel":                                  true,
+	"positionsel":                                      true,
+	"postgresql_fdw_validator":                         true,
+	"pow":                                              true,
+	"power":                                            true,
+	"prefixjoinsel":                                    true,
+	"prefixsel":                                        true,
+	"prsd_end":                                         true,
+	"prsd_headline":                                    true,
+	"prsd_lextype":                                     true,
+	"prsd_nexttoken":                                   true,
+	"prsd_start":                                       true,
+	"pt_contained_circle":                              true,
+	"pt_contained_poly":                                true,
+	"query_to_xml":                                     true,
+	"query_to_xml_and_xmlschema":                       true,
+	"query_to_xmlschema":                               true,
+	"querytree":                                        true,
+	"quote_ident":                                      true,
+	"quote_literal":                                    true,
+	"quote_nullable":                                   true,
+	"radians":                                          true,
+	"radius":                                           true,
+	"random":                                           true,
+	"random_normal":                                    true,
+	"range_adjacent":                                   true,
+	"range_adjacent_multirange":                        true,
+	"range_after":                                      true,
+	"range_after_multirange":                           true,
+	"range_agg":                                        true,
+	"range_agg_finalfn":                                true,
+	"range_agg_transfn":                                true,
+	"range_before":                                     true,
+	"range_before_multirange":                          true,
+	"range_cmp":                                        true,
+	"range_contained_by":                               true,
+	"range_contained_by_multirange":                    true,
+	"range_contains":                                   true,
+	"range_contains_elem":                              true,
+	"range_contains_elem_support":                      true,
+	"range_contains_multirange":                        true,
+	"range_eq":                                         true,
+	"range_ge":                                         true,
+	"range_gist_consistent":                            true,
+	"range_gist_penalty":                               true,
+	"range_gist_picksplit":                             true,
+	"range_gist_same":                                  true,
+	"range_gist_union":                                 true,
+	"range_gt":                                         true,
+	"range_in":                                         true,
+	"range_intersect":                                  true,
+	"range_intersect_agg":                              true,
+	"range_intersect_agg_transfn":                      true,
+	"range_le":                                         true,
+	"range_lt":                                         true,
+	"range_merge":                                      true,
+	"range_minus":                                      true,
+	"range_ne":                                         true,
+	"range_out":                                        true,
+	"range_overlaps":                                   true,
+	"range_overlaps_multirange":                        true,
+	"range_overleft":                                   true,
+	"range_overleft_multirange":                        true,
+	"range_overright":                                  true,
+	"range_overright_multirange":                       true,
+	"range_recv":                                       true,
+	"range_send":                                       true,
+	"range_typanalyze":                                 true,
+	"range_union":                                      true,
+	"rangesel":                                         true,
+	"rank":                                             true,
+	"rank_final":                                       true,
+	"raw_array_subscript_handler":                      true,
+	"record_eq":                                        true,
+	"record_ge":                                        true,
+	"record_gt":                                        true,
+	"record_image_eq":                                  true,
+	"record_image_ge":                                  true,
+	"record_image_gt":                                  true,
+	"record_image_le":                                  true,
+	"record_image_lt":                                  true,
+	"record_image_ne":                                  true,
+	"record_in":                                        true,
+	"record_le":                                        true,
+	"record_lt":                                        true,
+	"record_ne":                                        true,
+	"record_out":                                       true,
+	"record_recv":                                      true,
+	"record_send":                                      true,
+	"regclass":                                         true,
+	"regclassin":                                       true,
+	"regclassout":                                      true,
+	"regclassrecv":                                     true,
+	"regclasssend":                                     true,
+	"regcollationin":                                   true,
+	"regcollationout":                                  true,
+	"regcollationrecv":                                 true,
+	"regcollationsend":                                 true,
+	"regconfigin":                                      true,
+	"regconfigout":                                     true,
+	"regconfigrecv":                                    true,
+	"regconfigsend":                                    true,
+	"regdictionaryin":                                  true,
+	"regdictionaryout":                                 true,
+	"regdictionaryrecv":                                true,
+	"regdictionarysend":                                true,
+	"regexeqjoinsel":                                   true,
+	"regexeqsel":                                       true,
+	"regexnejoinsel":                                   true,
+	"regexnesel":                                       true,
+	"regexp_count":                                     true,
+	"regexp_count_text":                                true,
+	"regexp_count_text_text":                           true,
+	"regexp_instr":                                     true,
+	"regexp_instr_text":                                true,
+	"regexp_instr_text_text":                           true,
+	"regexp_instr_text_text_int4":                      true,
+	"regexp_instr_text_text_int4_int4":                 true,
+	"regexp_instr_text_text_int4_int4_int4":            true,
+	"regexp_like":                                      true,
+	"regexp_like_text":                                 true,
+	"regexp_match":                                     true,
+	"regexp_match_text":                                true,
+	"regexp_matches":                                   true,
+	"regexp_matches_text":                              true,
+	"regexp_replace":                                   true,
+	"regexp_replace_text":                              true,
+	"regexp_replace_text_text":                         true,
+	"regexp_replace_text_text_text":                    true,
+	"regexp_replace_text_text_text_int4":               true,
+	"regexp_split_to_array":                            true,
+	"regexp_split_to_array_text":                       true,
+	"regexp_split_to_table":                            true,
+	"regexp_split_to_table_text":                       true,
+	"regexp_substr":                                    true,
+	"regexp_substr_text":                               true,
+	"regexp_substr_text_text":                          true,
+	"regexp_substr_text_text_int4":                     true,
+	"regexp_substr_text_text_int4_int4":                true,
+	"regnamespacein":                                   true,
+	"regnamespaceout":                                  true,
+	"regnamespacerecv":                                 true,
+	"regnamespacesend":                                 true,
+	"regoperatorin":                                    true,
+	"regoperatorout":                                   true,
+	"regoperatorrecv":                                  true,
+	"regoperatorsend":                                  true,
+	"regoperin":                                        true,
+	"regoperout":                                       true,
+	"regoperrecv":                                      true,
+	"regopersend":                                      true,
+	"regprocedurein":                                   true,
+	"regprocedureout":                                  true,
+	"regprocedurerecv":                                 true,
+	"regproceduresend":                                 true,
+	"regprocin":                                        true,
+	"regprocout":                                       true,
+	"regprocrecv":                                      true,
+	"regprocsend":                                      true,
+	"regr_avgx":                                        true,
+	"regr_avgy":                                        true,
+	"regr_count":                                       true,
+	"regr_intercept":                                   true,
+	"regr_r2":                                          true,
+	"regr_slope":                                       true,
+	"regr_sxx":                                         true,
+	"regr_sxy":                                         true,
+	"regr_syy":                                         true,
+	"regrolein":                                        true,
+	"regroleout":                                       true,
+	"regrolerecv":                                      true,
+	"regrolesend":                                      true,
+	"regtypein":                                        true,
+	"regtypeout":                                       true,
+	"regtyperecv":                                      true,
+	"regtypesend":                                      true,
+	"repeat":                                           true,
+	"replace":                                          true,
+	"reverse":                                          true,
+	"ri_fkey_cascade_del":                              true,
+	"ri_fkey_cascade_upd":                              true,
+	"ri_fkey_check_ins":                                true,
+	"ri_fkey_check_upd":                                true,
+	"ri_fkey_noaction_del":                             true,
+	"ri_fkey_noaction_upd":                             true,
+	"ri_fkey_restrict_del":                             true,
+	"ri_fkey_restrict_upd":                             true,
+	"ri_fkey_setdefault_del":                           true,
+	"ri_fkey_setdefault_upd":                           true,
+	"ri_fkey_setnull_del":                              true,
+	"ri_fkey_setnull_upd":                              true,
+	"right":                                            true,
+	"round":                                            true,
+	"row_number":                                       true,
+	"row_security_active":                              true,
+	"row_to":                                           true,
+	"row_to_json":                                      true,
+	"rpad":                                             true,
+	"rpad_text":                                        true,
+	"rtrim":                                            true,
+	"satisfies_hash_partition":                         true,
+	"scalargejoinsel":                                  true,
+	"scalargesel":                                      true,
+	"scalargtjoinsel":                                  true,
+	"scalargtsel":                                      true,
+	"scalarlejoinsel":                                  true,
+	"scalarlesel":                                      true,
+	"scalarltjoinsel":                                  true,
+	"scalarltsel":                                      true,
+	"scale":                                            true,
+	"schema_to_xml":                                    true,
+	"schema_to_xml_and_xmlschema":                      true,
+	"schema_to_xmlschema":                              true,
+	"session_user":                                     true,
+	"set":                                              true,
+	"set_bit":                                          true,
+	"set_byte":                                         true,
+	"set_config":                                       true,
+	"set_masklen":                                      true,
+	"setseed":                                          true,
+	"setval":                                           true,
+	"setval_regclass":                                  true,
+	"setweight":                                        true,
+	"setweight_tsvector":                               true,
+	"sha224":                                           true,
+	"sha256":                                           true,
+	"sha384":                                           true,
+	"sha512":                                           true,
+	"shell_in":                                         true,
+	"shell_out":                                        true,
+	"shift_jis_2004_to_euc_jis_2004":                   true,
+	"shift_jis_2004_to_utf8":                           true,
+	"shobj_description":                                true,
+	"sign":                                             true,
+	"similar_escape":                                   true,
+	"similar_to_escape":                                true,
+	"sin":                                              true,
+	"sind":                                             true,
+	"sinh":                                             true,
+	"sjis_to_euc_jp":                                   true,
+	"sjis_to_mic":                                      true,
+	"sjis_to_utf8":                                     true,
+	"slope":                                            true,
+	"spg_bbox_quad_config":                             true,
+	"spg_box_quad_choose":                              true,
+	"spg_box_quad_config":                              true,
+	"spg_box_quad_inner_consistent":                    true,
+	"spg_box_quad_leaf_consistent":                     true,
+	"spg_box_quad_picksplit":                           true,
+	"spg_kd_choose":                                    true,
+	"spg_kd_config":                                    true,
+	"spg_kd_inner_consistent":                          true,
+	"spg_kd_picksplit":                                 true,
+	"spg_poly_quad_compress":                           true,
+	"spg_quad_choose":                                  true,
+	"spg_quad_config":                                  true,
+	"spg_quad_inner_consistent":                        true,
+	"spg_quad_leaf_consistent":                         true,
+	"spg_quad_picksplit":                               true,
+	"spg_range_quad_choose":                            true,
+	"spg_range_quad_config":                            true,
+	"spg_range_quad_inner_consistent":                  true,
+	"spg_range_quad_leaf_consistent":                   true,
+	"spg_range_quad_picksplit":                         true,
+	"spg_text_choose":                                  true,
+	"spg_text_config":                                  true,
+	"spg_text_inner_consistent":                        true,
+	"spg_text_leaf_consistent":                         true,
+	"spg_text_picksplit":                               true,
+	"spghandler":                                       true,
+	"split_part":                                       true,
+	"sqrt":                                             true,
+	"starts_with":                                      true,
+	"statement_timestamp":                              true,
+	"stddev":                                           true,
+	"stddev_pop":                                       true,
+	"stddev_samp":                                      true,
+	"string_agg":                                       true,
+	"string_agg_combine":                               true,
+	"string_agg_deserialize":                           true,
+	"string_agg_finalfn":                               true,
+	"string_agg_serialize":                             true,
+	"string_agg_transfn":                               true,
+	"string_to_array":                                  true,
+	"string_to_array_text":                             true,
+	"string_to_table":                                  true,
+	"string_to_table_text":                             true,
+	"strip":                                            true,
+	"strpos":                                           true,
+	"substr":                                           true,
+	"substr_bytea":                                     true,
+	"substr_text":                                      true,
+	"substring":                                        true,
+	"substring_bit":                                    true,
+	"substring_bytea":                                  true,
+	"substring_text":                                   true,
+	"sum":                                              true,
+	"suppress_redundant_updates_trigger":               true,
+	"system":                                           true,
+	"system_user":                                      true,
+	"table_am_handler_in":                              true,
+	"table_am_handler_out":                             true,
+	"table_to_xml":                                     true,
+	"table_to_xml_and_xmlschema":                       true,
+	"table_to_xmlschema":                               true,
+	"tan":                                              true,
+	"tand":                                             true,
+	"tanh":                                             true,
+	"text":                                             true,
+	"text_char":                                        true,
+	"text_ge":                                          true,
+	"text_gt":                                          true,
+	"text_larger":                                      true,
+	"text_le":                                          true,
+	"text_lt":                                          true,
+	"text_name":                                        true,
+	"text_pattern_ge":                                  true,
+	"text_pattern_gt":                                  true,
+	"text_pattern_le":                                  true,
+	"text_pattern_lt":                                  true,
+	"text_smaller":                                     true,
+	"text_starts_with_support":                         true,
+	"textanycat":                                       true,
+	"textcat":                                          true,
+	"texteq":                                           true,
+	"texteqname":                                       true,
+	"textgename":                                       true,
+	"textgtname":                                       true,
+	"texticlike":                                       true,
+	"texticlike_support":                               true,
+	"texticnlike":                                      true,
+	"texticregexeq":                                    true,
+	"texticregexeq_support":                            true,
+	"texticregexne":                                    true,
+	"textin":                                           true,
+	"textlen":                                          true,
+	"textlename":                                       true,
+	"textlike":                                         true,
+	"textlike_support":                                 true,
+	"textltname":                                       true,
+	"textne":                                           true,
+	"textnename":                                       true,
+	"textnlike":                                        true,
+	"textout":                                          true,
+	"textrecv":                                         true,
+	"textregexeq":                                      true,
+	"textregexeq_support":                              true,
+	"textregexne":                                      true,
+	"textsend":                                         true,
+	"thesaurus_init":                                   true,
+	"thesaurus_lexize":                                 true,
+	"tideq":                                            true,
+	"tidge":                                            true,
+	"tidgt":                                            true,
+	"tidin":                                            true,
+	"tidlarger":                                        true,
+	"tidle":                                            true,
+	"tidlt":                                            true,
+	"tidne":                                            true,
+	"tidout":                                           true,
+	"tidrecv":                                          true,
+	"tidsend":                                          true,
+	"tidsmaller":                                       true,
+	"time":                                             true,
+	"time_cmp":                                         true,
+	"time_eq":                                          true,
+	"time_ge":                                          true,
+	"time_gt":                                          true,
+	"time_hash":                                        true,
+	"time_hash_extended":                               true,
+	"time_in":                                          true,
+	"time_interval":                                    true,
+	"time_larger":                                      true,
+	"time_le":                                          true,
+	"time_lt":                                          true,
+	"time_mi":                                          true,
+	"time_mi_interval":                                 true,
+	"time_mi_time":                                     true,
+	"time_ne":                                          true,
+	"time_out":                                         true,
+	"time_pl_interval":                                 true,
+	"time_recv":                                        true,
+	"time_send":                                        true,
+	"time_smaller":                                     true,
+	"time_support":                                     true,
+	"time_timetz":                                      true,
+	"timedate_pl":                                      true,
+	"timeofday":                                        true,
+	"timestamp":                                        true,
+	"timestamp_cmp":                                    true,
+	"timestamp_cmp_date":                               true,
+	"timestamp_cmp_timestamptz":                        true,
+	"timestamp_date":                                   true,
+	"timestamp_eq":                                     true,
+	"timestamp_eq_date":                                true,
+	"timestamp_eq_timestamptz":                         true,
+	"timestamp_ge":                                     true,
+	"timestamp_ge_date":                                true,
+	"timestamp_ge_timestamptz":                         true,
+	"timestamp_gt":                                     true,
+	"timestamp_gt_date":                                true,
+	"timestamp_gt_timestamptz":                         true,
+	"timestamp_hash":                                   true,
+	"timestamp_hash_extended":                          true,
+	"timestamp_in":                                     true,
+	"timestamp_larger":                                 true,
+	"timestamp_le":                                     true,
+	"timestamp_le_date":                                true,
+	"timestamp_le_timestamptz":                         true,
+	"timestamp_lt":                                     true,
+	"timestamp_lt_date":                                true,
+	"timestamp_lt_timestamptz":                         true,
+	"timestamp_mi":                                     true,
+	"timestamp_mi_interval":                            true,
+	"timestamp_ne":                                     true,
+	"timestamp_ne_date":                                true,
+	"timestamp_ne_timestamptz":                         true,
+	"timestamp_out":                                    true,
+	"timestamp_pl_interval":                            true,
+	"timestamp_recv":                                   true,
+	"timestamp_send":                                   true,
+	"timestamp_smaller":                                true,
+	"timestamp_sortsupport":                            true,
+	"timestamp_support":                                true,
+	"timestamp_timestamptz":                            true,
+	"timestamptypmodin":                                true,
+	"timestamptypmodout":                               true,
+	"timestamptz":                                      true,
+	"timestamptz_cmp":                                  true,
+	"timestamptz_cmp_date":                             true,
+	"timestamptz_cmp_timestamp":                        true,
+	"timestamptz_date":                                 true,
+	"timestamptz_eq":                                   true,
+	"timestamptz_eq_date":                              true,
+	"timestamptz_eq_timestamp":                         true,
+	"timestamptz_ge":                                   true,
+	"timestamptz_ge_date":                              true,
+	"timestamptz_ge_timestamp":                         true,
+	"timestamptz_gt":                                   true,
+	"timestamptz_gt_date":                              true,
+	"timestamptz_gt_timestamp":                         true,
+	"timestamptz_in":                                   true,
+	"timestamptz_larger":                               true,
+	"timestamptz_le":                                   true,
+	"timestamptz_le_date":                              true,
+	"timestamptz_le_timestamp":                         true,
+	"timestamptz_lt":                                   true,
+	"timestamptz_lt_date":                              true,
+	"timestamptz_lt_timestamp":                         true,
+	"timestamptz_mi":                                   true,
+	"timestamptz_mi_interval":                          true,
+	"timestamptz_ne":                                   true,
+	"timestamptz_ne_date":                              true,
+	"timestamptz_ne_timestamp":                         true,
+	"timestamptz_out":                                  true,
+	"timestamptz_pl_interval":                          true,
+	"timestamptz_recv":                                 true,
+	"timestamptz_send":                                 true,
+	"timestamptz_smaller":                              true,
+	"timestamptz_timestamp":                            true,
+	"timestamptztypmodin":                              true,
+	"timestamptztypmodout":                             true,
+	"timetypmodin":                                     true,
+	"timetypmodout":                                    true,
+	"timetz":                                           true,
+	"timetz_cmp":                                       true,
+	"timetz_eq":                                        true,
+	"timetz_ge":                                        true,
+	"timetz_gt":                                        true,
+	"timetz_hash":                                      true,
+	"timetz_hash_extended":                             true,
+	"timetz_in":                                        true,
+	"timetz_larger":                                    true,
+	"timetz_le":                                        true,
+	"timetz_lt":                                        true,
+	"timetz_mi_interval":                               true,
+	"timetz_ne":                                        true,
+	"timetz_out":                                       true,
+	"timetz_pl_interval":                               true,
+	"timetz_recv":                                      true,
+	"timetz_send":                                      true,
+	"timetz_smaller":                                   true,
+	"timetz_time":                                      true,
+	"timetzdate_pl":                                    true,
+	"timetztypmodin":                                   true,
+	"timetztypmodout":                                  true,
+	"timezone":                                         true,
+	"timezone_interval":                                true,
+	"timezone_text":                                    true,
+	"to":                                               true,
+	"to_ascii":                                         true,
+	"to_ascii_text":                                    true,
+	"to_bin":                                           true,
+	"to_char":                                          true,
+	"to_date":                                          true,
+	"to_hex":                                           true,
+	"to_json":                                          true,
+	"to_jsonb":                                         true,
+	"to_number":                                        true,
+	"to_oct":                                           true,
+	"to_regclass":                                      true,
+	"to_regcollation":                                  true,
+	"to_regnamespace":                                  true,
+	"to_regoper":                                       true,
+	"to_regoperator":                                   true,
+	"to_regproc":                                       true,
+	"to_regprocedure":                                  true,
+	"to_regrole":                                       true,
+	"to_regtype":                                       true,
+	"to_regtypemod":                                    true,
+	"to_timestamp":                                     true,
+	"to_tsquery":                                       true,
+	"to_tsvector":                                      true,
+	"to_tsvector_regconfig":                            true,
+	"transaction_timestamp":                            true,
+	"translate":                                        true,
+	"trigger_in":                                       true,
+	"trigger_out":                                      true,
+	"trim_array":                                       true,
+	"trim_scale":                                       true,
+	"trunc":                                            true,
+	"ts_debug":                                         true,
+	"ts_delete":                                        true,
+	"ts_delete_tsvector":                               true,
+	"ts_filter":                                        true,
+	"ts_headline":                                      true,
+	"ts_headline_json":                                 true,
+	"ts_headline_jsonb":                                true,
+	"ts_headline_regconfig":                            true,
+	"ts_headline_regconfig_json":                       true,
+	"ts_headline_regconfig_jsonb":                      true,
+	"ts_headline_regconfig_text":                       true,
+	"ts_headline_text":                                 true,
+	"ts_lexize":                                        true,
+	"ts_match_qv":                                      true,
+	"ts_match_tq":                                      true,
+	"ts_match_tt":                                      true,
+	"ts_match_vq":                                      true,
+	"ts_parse":                                         true,
+	"ts_rank":                                          true,
+	"ts_rank_":                                         true,
+	"ts_rank__float4":                                  true,
+	"ts_rank__float4_tsvector":                         true,
+	"ts_rank_cd":                                       true,
+	"ts_rank_cd_":                                      true,
+	"ts_rank_cd__float4":                               true,
+	"ts_rank_cd__float4_tsvector":                      true,
+	"ts_rank_cd_tsvector":                              true,
+	"ts_rank_tsvector":                                 true,
+	"ts_rewrite":                                       true,
+	"ts_rewrite_tsquery":                               true,
+	"ts_stat":                                          true,
+	"ts_token_type":                                    true,
+	"ts_typanalyze":                                    true,
+	"tsm_handler_in":                                   true,
+	"tsm_handler_out":                                  true,
+	"tsmatchjoinsel":                                   true,
+	"tsmatchsel":                                       true,
+	"tsmultirange":                                     true,
+	"tsq_mcontained":                                   true,
+	"tsq_mcontains":                                    true,
+	"tsquery_and":                                      true,
+	"tsquery_cmp":                                      true,
+	"tsquery_eq":                                       true,
+	"tsquery_ge":                                       true,
+	"tsquery_gt":                                       true,
+	"tsquery_le":                                       true,
+	"tsquery_lt":                                       true,
+	"tsquery_ne":                                       true,
+	"tsquery_not":                                      true,
+	"tsquery_or":                                       true,
+	"tsquery_phrase":                                   true,
+	"tsquery_phrase_tsquery":                           true,
+	"tsqueryin":                                        true,
+	"tsqueryout":                                       true,
+	"tsqueryrecv":                                      true,
+	"tsquerysend":                                      true,
+	"tsrange":                                          true,
+	"tsrange_subdiff":                                  true,
+	"tsrange_timestamp":                                true,
+	"tstzmultirange":                                   true,
+	"tstzrange":                                        true,
+	"tstzrange_subdiff":                                true,
+	"tstzrange_timestamptz":                            true,
+	"tsvector_cmp":                                     true,
+	"tsvector_concat":                                  true,
+	"tsvector_eq":                                      true,
+	"tsvector_ge":                                      true,
+	"tsvector_gt":                                      true,
+	"tsvector_le":                                      true,
+	"tsvector_lt":                                      true,
+	"tsvector_ne":                                      true,
+	"tsvector_to_array":                                true,
+	"tsvector_update_trigger":                          true,
+	"tsvector_update_trigger_column":                   true,
+	"tsvectorin":                                       true,
+	"tsvectorout":                                      true,
+	"tsvectorrecv":                                     true,
+	"tsvectorsend":                                     true,
+	"txid_current":                                     true,
+	"txid_current_if_assigned":                         true,
+	"txid_current_snapshot":                            true,
+	"txid_snapshot_in":                                 true,
+	"txid_snapshot_out":                                true,
+	"txid_snapshot_recv":                               true,
+	"txid_snapshot_send":                               true,
+	"txid_snapshot_xip":                                true,
+	"txid_snapshot_xmax":                               true,
+	"txid_snapshot_xmin":                               true,
+	"txid_status":                                      true,
+	"txid_visible_in_snapshot":                         true,
+	"uhc_to_utf8":                                      true,
+	"unicode_assigned":                                 true,
+	"unicode_version":                                  true,
+	"unique_key_recheck":                               true,
+	"unistr":                                           true,
+	"unknownin":                                        true,
+	"unknownout":                                       true,
+	"unknownrecv":                                      true,
+	"unknownsend":                                      true,
+	"unnest":                                           true,
+	"upper":                                            true,
+	"upper_inc":                                        true,
+	"upper_inf":                                        true,
+	"utf8_to_big5":                                     true,
+	"utf8_to_euc_cn":                                   true,
+	"utf8_to_euc_jis_2004":                             true,
+	"utf8_to_euc_jp":                                   true,
+	"utf8_to_euc_kr":                                   true,
+	"utf8_to_euc_tw":                                   true,
+	"utf8_to_gb18030":                                  true,
+	"utf8_to_gbk":                                      true,
+	"utf8_to_iso8859":                                  true,
+	"utf8_to_iso8859_1":                                true,
+	"utf8_to_johab":                                    true,
+	"utf8_to_koi8r":                                    true,
+	"utf8_to_koi8u":                                    true,
+	"utf8_to_shift_jis_2004":                           true,
+	"utf8_to_sjis":                                     true,
+	"utf8_to_uhc":                                      true,
+	"utf8_to_win":                                      true,
+	"uuid_cmp":                                         true,
+	"uuid_eq":                                          true,
+	"uuid_extract_timestamp":                           true,
+	"uuid_extract_version":                             true,
+	"uuid_ge":                                          true,
+	"uuid_gt":                                          true,
+	"uuid_hash":                                        true,
+	"uuid_hash_extended":                               true,
+	"uuid_in":                                          true,
+	"uuid_le":                                          true,
+	"uuid_lt":                                          true,
+	"uuid_ne":                                          true,
+	"uuid_out":                                         true,
+	"uuid_recv":                                        true,
+	"uuid_send":                                        true,
+	"uuid_sortsupport":                                 true,
+	"var_pop":                                          true,
+	"var_samp":                                         true,
+	"varbit":                                           true,
+	"varbit_in":                                        true,
+	"varbit_out":                                       true,
+	"varbit_recv":                                      true,
+	"varbit_send":                                      true,
+	"varbit_support":                                   true,
+	"varbitcmp":                                        true,
+	"varbiteq":                                         true,
+	"varbitge":                                         true,
+	"varbitgt":                                         true,
+	"varbitle":                                         true,
+	"varbitlt":                                         true,
+	"varbitne":                                         true,
+	"varbittypmodin":                                   true,
+	"varbittypmodout":                                  true,
+	"varchar":                                          true,
+	"varchar_support":                                  true,
+	"varcharin":                                        true,
+	"varcharout":                                       true,
+	"varcharrecv":                                      true,
+	"varcharsend":                                      true,
+	"varchartypmodin":                                  true,
+	"varchartypmodout":                                 true,
+	"variance":                                         true,
+	"version":                                          true,
+	"void_in":                                          true,
+	"void_out":                                         true,
+	"void_recv":                                        true,
+	"void_send":                                        true,
+	"websearch_to":                                     true,
+	"websearch_to_tsquery":                             true,
+	"width":                                            true,
+	"width_bucket":                                     true,
+	"win1250_to_latin2":                                true,
+	"win1250_to_mic":                                   true,
+	"win1251_to_iso":                                   true,
+	"win1251_to_koi8r":                                 true,
+	"win1251_to_mic":                                   true,
+	"win1251_to_win866":                                true,
+	"win866_to_iso":                                    true,
+	"win866_to_koi8r":                                  true,
+	"win866_to_mic":                                    true,
+	"win866_to_win1251":                                true,
+	"win_to_utf8":                                      true,
+	"window_cume_dist_support":                         true,
+	"window_dense_rank_support":                        true,
+	"window_ntile_support":                             true,
+	"window_percent_rank_support":                      true,
+	"window_rank_support":                              true,
+	"window_row_number_support":                        true,
+	"xid":                                              true,
+	"xid8_larger":                                      true,
+	"xid8_smaller":                                     true,
+	"xid8cmp":                                          true,
+	"xid8eq":                                           true,
+	"xid8ge":                                           true,
+	"xid8gt":                                           true,
+	"xid8in":                                           true,
+	"xid8le":                                           true,
+	"xid8lt":                                           true,
+	"xid8ne":                                           true,
+	"xid8out":                                          true,
+	"xid8recv":                                         true,
+	"xid8send":                                         true,
+	"xideq":                                            true,
+	"xideqint4":                                        true,
+	"xidin":                                            true,
+	"xidneq":                                           true,
+	"xidneqint4":                                       true,
+	"xidout":                                           true,
+	"xidrecv":                                          true,
+	"xidsend":                                          true,
+	"xml":                                              true,
+	"xml_in":                                           true,
+	"xml_is_well_formed":                               true,
+	"xml_is_well_formed_content":                       true,
+	"xml_is_well_formed_document":                      true,
+	"xml_out":                                          true,
+	"xml_recv":                                         true,
+	"xml_send":                                         true,
+	"xmlagg":                                           true,
+	"xmlcomment":                                       true,
+	"xmlconcat2":                                       true,
+	"xmlexists":                                        true,
+	"xmltext":                                          true,
+	"xmlvalidate":                                      true,
+	"xpath":                                            true,
+	"xpath_exists":                                     true,
+	"xpath_exists_text":                                true,
+	"xpath_text":                                       true,
+}
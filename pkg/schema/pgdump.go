@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/wasilibs/go-pgquery/parser"
+)
+
+var copyFromStdinRe = regexp.MustCompile(`(?i)^COPY\s.*\sFROM\s+stdin`)
+
+// stripPsqlCommands blanks out psql meta-commands, e.g. \connect, and data of
+// COPY ... FROM stdin blocks found in pg_dump output, neither can be parsed
+// as SQL. Line numbers are kept intact for error reporting.
+func stripPsqlCommands(input string) string {
+	lines := strings.Split(input, "\n")
+	inCopy := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inCopy:
+			// data ends with \. line
+			inCopy = trimmed != `\.`
+			lines[i] = ""
+		case strings.HasPrefix(trimmed, `\`):
+			lines[i] = ""
+		case copyFromStdinRe.MatchString(trimmed):
+			inCopy = true
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineOf returns line number of byte offset in input, starting from 1
+func lineOf(input string, offset int) int {
+	if offset > len(input) {
+		offset = len(input)
+	}
+	return strings.Count(input[:offset], "\n") + 1
+}
+
+// parseErrorLine returns line number of syntax error reported by parser, 0
+// when parser doesn't report error position
+func parseErrorLine(input string, err error) int {
+	var pgErr *parser.Error
+	if !errors.As(err, &pgErr) || pgErr.Cursorpos <= 0 {
+		return 0
+	}
+	// cursor position counts characters starting from 1
+	chars := 0
+	for offset := range input {
+		chars++
+		if chars == pgErr.Cursorpos {
+			return lineOf(input, offset)
+		}
+	}
+	return lineOf(input, len(input))
+}
+
+// stmtLine returns line number of the first token of a statement, statement
+// location may include whitespace and comments following previous statement.
+func stmtLine(input string, stmt *pg_query.RawStmt) int {
+	offset := int(stmt.GetStmtLocation())
+	for offset < len(input) {
+		rest := input[offset:]
+		switch {
+		case strings.HasPrefix(rest, "--"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return lineOf(input, offset)
+			}
+			offset += end + 1
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest, "*/")
+			if end < 0 {
+				return lineOf(input, offset)
+			}
+			offset += end + 2
+		case strings.TrimLeft(rest[:1], " \t\r\n") == "":
+			offset++
+		default:
+			return lineOf(input, offset)
+		}
+	}
+	return lineOf(input, offset)
+}
@@ -1,87 +1,365 @@
 package schema
 
 import (
-	"os"
+	"fmt"
 	"strings"
 
 	pg_query "github.com/pganalyze/pg_query_go/v6"
-	pg_wasm "github.com/wasilibs/go-pgquery"
+
+	"github.com/houqp/sqlvet/pkg/parseutil"
 )
 
-func (s *Db) LoadPostgres(schemaPath string) error {
-	schemaBytes, err := os.ReadFile(schemaPath)
-	if err != nil {
-		return err
+// LoadPostgres loads schema from DDL scripts, or from up migrations for
+// paths pointing to migrations directories. Paths can be glob patterns,
+// sources are applied in order on top of each other.
+func (s *Db) LoadPostgres(schemaPaths ...string) error {
+	loader := newPgSchemaLoader()
+	loader.viewColumns = s.ViewColumns
+	for _, path := range s.Exclude {
+		loader.exclude[absPath(path)] = true
 	}
-
-	s.Tables, err = parsePostgresSchema(string(schemaBytes))
+	paths, err := expandSchemaPaths(schemaPaths, loader.exclude)
 	if err != nil {
 		return err
 	}
+	for _, path := range paths {
+		if err := loader.applySource(path); err != nil {
+			if len(paths) > 1 {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			return err
+		}
+	}
 
+	s.Tables = loader.tables
+	s.Functions = loader.functions
+	s.Extensions = loader.extensions
 	return nil
 }
 
 func parsePostgresSchema(schemaInput string) (map[string]Table, error) {
-	tables := map[string]Table{}
-	tree, err := pg_wasm.Parse(schemaInput)
-	if err != nil {
+	loader := newPgSchemaLoader()
+	if err := loader.apply(schemaInput); err != nil {
 		return nil, err
 	}
+	return loader.tables, nil
+}
+
+// pgSchemaLoader replays DDL statements, unqualified names are resolved
+// through search path the same way postgres does
+type pgSchemaLoader struct {
+	tables     map[string]Table
+	enums      map[string]*Enum
+	functions  map[string][]Function
+	extensions []string
+	searchPath []string
+
+	// schema file or migrations directory being applied and the source
+	// each table was created by
+	source       string
+	tableSources map[string]string
+
+	viewColumns ViewColumnsFunc
+	// exclude holds absolute paths of schema files not to apply
+	exclude map[string]bool
+}
+
+func newPgSchemaLoader() *pgSchemaLoader {
+	return &pgSchemaLoader{
+		tables:     map[string]Table{},
+		enums:      map[string]*Enum{},
+		functions:  map[string][]Function{},
+		searchPath: DefaultSearchPath,
+
+		tableSources: map[string]string{},
+		exclude:      map[string]bool{},
+	}
+}
+
+// creationSchema returns schema new objects are created in, qualifier is
+// the schema given in object name if any
+func (l *pgSchemaLoader) creationSchema(qualifier string) string {
+	if qualifier != "" {
+		return qualifier
+	}
+	if len(l.searchPath) == 0 {
+		return "public"
+	}
+	return l.searchPath[0]
+}
+
+// lookup returns key of an existing relation
+func (l *pgSchemaLoader) lookup(rv *pg_query.RangeVar) (string, bool) {
+	t, ok := LookupTable(l.tables, l.searchPath, rv.GetSchemaname(), rv.GetRelname())
+	return t.Key(), ok
+}
+
+// apply applies DDL statements in schemaInput to loaded tables. Errors are
+// prefixed with line number of the failing statement.
+func (l *pgSchemaLoader) apply(schemaInput string) error {
+	schemaInput = stripPsqlCommands(schemaInput)
+	tree, err := parseutil.Parse(schemaInput)
+	if err != nil {
+		if line := parseErrorLine(schemaInput, err); line > 0 {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		return err
+	}
 
 	for _, stmt := range tree.GetStmts() {
 		if stmt.GetStmt() == nil {
 			continue
 		}
+		if err := l.applyStmt(stmt.GetStmt(), ""); err != nil {
+			return fmt.Errorf("line %d: %w", stmtLine(schemaInput, stmt), err)
+		}
+	}
 
-		// Check if this is a CREATE TABLE statement
-		if createStmt := stmt.GetStmt().GetCreateStmt(); createStmt != nil {
-			tableName := createStmt.GetRelation().GetRelname()
-			table := Table{
-				Name:    tableName,
-				Columns: map[string]Column{},
-			}
+	return nil
+}
 
-			for _, colElem := range createStmt.GetTableElts() {
-				if colDef := colElem.GetColumnDef(); colDef != nil {
-					typeParts := []string{}
-					for _, typNode := range colDef.GetTypeName().GetNames() {
-						if tStr := typNode.GetString_(); tStr != nil {
-							typeParts = append(typeParts, tStr.GetSval())
-						}
-					}
-
-					colName := colDef.GetColname()
-					table.Columns[colName] = Column{
-						Name: colName,
-						Type: strings.Join(typeParts, "."),
-					}
-				}
+// applyStmt applies a DDL statement, schemaName is set for statements
+// nested in CREATE SCHEMA.
+func (l *pgSchemaLoader) applyStmt(node *pg_query.Node, schemaName string) error {
+	// Check if this is a CREATE TABLE statement
+	if createStmt := node.GetCreateStmt(); createStmt != nil {
+		if schemaName == "" {
+			schemaName = l.creationSchema(createStmt.GetRelation().GetSchemaname())
+		}
+		table := Table{
+			Name:    createStmt.GetRelation().GetRelname(),
+			Schema:  schemaName,
+			Columns: map[string]Column{},
+		}
+
+		// INHERITS (parent) and PARTITION OF parent start out with columns
+		// of the parent
+		for _, inh := range createStmt.GetInhRelations() {
+			l.inheritColumns(table, inh.GetRangeVar(), createStmt.GetPartbound() != nil)
+		}
+
+		for _, colElem := range createStmt.GetTableElts() {
+			if colDef := colElem.GetColumnDef(); colDef != nil {
+				l.addColumnDef(table, colDef)
+			}
+			if like := colElem.GetTableLikeClause(); like != nil {
+				l.copyLikeColumns(table, like)
 			}
+		}
+
+		// table constraints, e.g. PRIMARY KEY (id)
+		for _, elem := range createStmt.GetTableElts() {
+			applyTableConstraint(table, elem.GetConstraint())
+		}
+
+		if err := l.checkTableConflict(table); err != nil {
+			return err
+		}
+		if _, ok := l.tables[table.Key()]; !ok {
+			// identical definitions from later sources keep the first one
+			l.tableSources[table.Key()] = l.source
+		}
+		l.tables[table.Key()] = table
+	}
+
+	// Check if this is a CREATE VIEW statement, CREATE OR REPLACE VIEW
+	// overrides previous definition
+	if viewStmt := node.GetViewStmt(); viewStmt != nil {
+		if schemaName == "" {
+			schemaName = l.creationSchema(viewStmt.GetView().GetSchemaname())
+		}
+		table := Table{
+			Name:     viewStmt.GetView().GetRelname(),
+			Schema:   schemaName,
+			Columns:  map[string]Column{},
+			ReadOnly: true,
+		}
+
+		for _, colName := range l.derivedColumnNames(viewStmt.GetQuery(), viewStmt.GetAliases()) {
+			table.addColumn(Column{Name: colName})
+		}
+
+		l.tables[table.Key()] = table
+	}
 
-			tables[tableName] = table
+	// CREATE MATERIALIZED VIEW and CREATE TABLE AS, materialized views can
+	// only be changed through REFRESH
+	if ctasStmt := node.GetCreateTableAsStmt(); ctasStmt != nil {
+		rel := ctasStmt.GetInto().GetRel()
+		if schemaName == "" {
+			schemaName = l.creationSchema(rel.GetSchemaname())
 		}
+		table := Table{
+			Name:     rel.GetRelname(),
+			Schema:   schemaName,
+			Columns:  map[string]Column{},
+			ReadOnly: ctasStmt.GetObjtype() == pg_query.ObjectType_OBJECT_MATVIEW,
+		}
+		if _, exists := l.tables[table.Key()]; !exists || !ctasStmt.GetIfNotExists() {
+			for _, colName := range l.derivedColumnNames(ctasStmt.GetQuery(), ctasStmt.GetInto().GetColNames()) {
+				table.addColumn(Column{Name: colName})
+			}
+			l.tables[table.Key()] = table
+		}
+	}
+
+	// CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy')
+	if enumStmt := node.GetCreateEnumStmt(); enumStmt != nil {
+		l.applyCreateEnum(enumStmt, schemaName)
+	}
+	if alterEnumStmt := node.GetAlterEnumStmt(); alterEnumStmt != nil {
+		l.applyAlterEnum(alterEnumStmt)
+	}
 
-		// Check if this is a CREATE VIEW statement
-		if viewStmt := stmt.GetStmt().GetViewStmt(); viewStmt != nil {
-			tableName := viewStmt.GetView().GetRelname()
-			table := Table{
-				Name:     tableName,
-				Columns:  map[string]Column{},
-				ReadOnly: true,
+	// CREATE FUNCTION and CREATE AGGREGATE extend built-in function catalog
+	if funcStmt := node.GetCreateFunctionStmt(); funcStmt != nil {
+		l.applyCreateFunction(funcStmt, schemaName)
+	}
+	if defineStmt := node.GetDefineStmt(); defineStmt != nil && defineStmt.GetKind() == pg_query.ObjectType_OBJECT_AGGREGATE {
+		l.applyCreateAggregate(defineStmt, schemaName)
+	}
+	if extStmt := node.GetCreateExtensionStmt(); extStmt != nil {
+		l.extensions = append(l.extensions, extStmt.GetExtname())
+	}
+
+	// CREATE SCHEMA auth CREATE TABLE users (...)
+	if schemaStmt := node.GetCreateSchemaStmt(); schemaStmt != nil {
+		for _, elt := range schemaStmt.GetSchemaElts() {
+			if err := l.applyStmt(elt, schemaStmt.GetSchemaname()); err != nil {
+				return err
 			}
+		}
+	}
 
-			// Extract columns from the view's SELECT statement
-			columns := extractColumnsFromViewQuery(viewStmt.GetQuery())
-			for _, colName := range columns {
-				table.Columns[colName] = Column{Name: colName}
+	// SET search_path TO auth, public
+	if setStmt := node.GetVariableSetStmt(); setStmt != nil && setStmt.GetName() == "search_path" {
+		l.searchPath = []string{}
+		for _, arg := range setStmt.GetArgs() {
+			if name := arg.GetAConst().GetSval().GetSval(); name != "" {
+				l.searchPath = append(l.searchPath, name)
 			}
+		}
+	}
+
+	// Replay schema changes, e.g. from migrations
+	if alterStmt := node.GetAlterTableStmt(); alterStmt != nil {
+		if err := l.applyAlterTable(alterStmt); err != nil {
+			return err
+		}
+	}
+	if renameStmt := node.GetRenameStmt(); renameStmt != nil {
+		if err := l.applyRename(renameStmt); err != nil {
+			return err
+		}
+	}
+	if dropStmt := node.GetDropStmt(); dropStmt != nil {
+		l.applyDrop(dropStmt)
+	}
+	return nil
+}
+
+// parseColumnDef converts column definition from CREATE TABLE or ALTER TABLE
+// ADD COLUMN
+func (l *pgSchemaLoader) parseColumnDef(colDef *pg_query.ColumnDef) Column {
+	col := Column{
+		Name:    colDef.GetColname(),
+		Type:    typeNameString(colDef.GetTypeName()),
+		NotNull: colDef.GetIsNotNull(),
+	}
+	col.Enum = l.columnEnum(col.Type)
+	applyColumnConstraints(&col, colDef.GetConstraints())
+	return col
+}
 
-			tables[tableName] = table
+// typeNameString joins qualified type name, e.g. pg_catalog.int4, array
+// types are suffixed with [].
+func typeNameString(typeName *pg_query.TypeName) string {
+	typeParts := []string{}
+	for _, typNode := range typeName.GetNames() {
+		if tStr := typNode.GetString_(); tStr != nil {
+			typeParts = append(typeParts, tStr.GetSval())
 		}
 	}
 
-	return tables, nil
+	colType := strings.Join(typeParts, ".")
+	if len(typeName.GetArrayBounds()) > 0 {
+		colType += "[]"
+	}
+	return colType
+}
+
+// applyTableConstraint marks PRIMARY KEY columns as NOT NULL
+func applyTableConstraint(table Table, cons *pg_query.Constraint) {
+	if cons.GetContype() != pg_query.ConstrType_CONSTR_PRIMARY {
+		return
+	}
+	for _, key := range cons.GetKeys() {
+		if col, ok := table.Columns[key.GetString_().GetSval()]; ok {
+			col.NotNull = true
+			table.Columns[col.Name] = col
+		}
+	}
+}
+
+// serial types are integer columns with sequence backed default
+var serialTypes = map[string]bool{
+	"smallserial": true, "serial": true, "bigserial": true,
+	"serial2": true, "serial4": true, "serial8": true,
+}
+
+// applyColumnConstraints sets nullability, default and generated column info
+// from constraints defined inline with the column.
+func applyColumnConstraints(col *Column, constraints []*pg_query.Node) {
+	if serialTypes[strings.ToLower(col.Type)] {
+		col.NotNull = true
+		col.HasDefault = true
+	}
+	for _, node := range constraints {
+		cons := node.GetConstraint()
+		if cons == nil {
+			continue
+		}
+		switch cons.GetContype() {
+		case pg_query.ConstrType_CONSTR_NOTNULL, pg_query.ConstrType_CONSTR_PRIMARY:
+			col.NotNull = true
+		case pg_query.ConstrType_CONSTR_NULL:
+			col.NotNull = false
+		case pg_query.ConstrType_CONSTR_DEFAULT:
+			col.HasDefault = true
+		case pg_query.ConstrType_CONSTR_IDENTITY:
+			// identity columns are implicitly NOT NULL
+			col.NotNull = true
+			col.HasDefault = true
+			col.Identity = true
+			col.GeneratedAlways = cons.GetGeneratedWhen() == "a"
+		case pg_query.ConstrType_CONSTR_GENERATED:
+			// only GENERATED ALWAYS AS (...) STORED is supported by postgres
+			col.HasDefault = true
+			col.GeneratedAlways = true
+		}
+	}
+}
+
+// derivedColumnNames derives columns of a view or materialized view, names
+// from top-level target list of the query are used when it can't be
+// resolved. Column names given in CREATE VIEW v (a, b) override derived ones
+// in order.
+func (l *pgSchemaLoader) derivedColumnNames(query *pg_query.Node, aliases []*pg_query.Node) []string {
+	var columns []string
+	ok := false
+	if l.viewColumns != nil {
+		columns, ok = l.viewColumns(l.tables, l.searchPath, query)
+	}
+	if !ok {
+		columns = extractColumnsFromViewQuery(query)
+	}
+
+	for i, alias := range aliases {
+		if i < len(columns) {
+			columns[i] = alias.GetString_().GetSval()
+		}
+	}
+	return columns
 }
 
 // extractColumnsFromViewQuery extracts column names from a view's query
@@ -185,11 +463,6 @@ func extractColumnNameFromValue(val *pg_query.Node) string {
 		return extractColumnNameFromColumnRef(colRef)
 	}
 
-	// Handle A_Star (wildcard *)
-	if star := val.GetAStar(); star != nil {
-		return "*"
-	}
-
 	// Handle FuncCall (e.g., "count(*)")
 	if funcCall := val.GetFuncCall(); funcCall != nil {
 		return extractColumnNameFromFuncCall(funcCall)
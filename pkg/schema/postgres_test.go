@@ -3,6 +3,7 @@ package schema
 import (
 	"testing"
 
+	pg_query "github.com/pganalyze/pg_query_go/v6"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,15 +33,20 @@ CREATE TABLE public.users (
 				require.NoError(t, err)
 				require.Equal(t, map[string]Table{
 					"users": {
-						Name: "users",
+						Name:   "users",
+						Schema: "public",
 						Columns: map[string]Column{
 							"id": {
-								Name: "id",
-								Type: "pg_catalog.int4",
+								Name:     "id",
+								Type:     "pg_catalog.int4",
+								Position: 1,
+								NotNull:  true,
 							},
 							"name": {
-								Name: "name",
-								Type: "text",
+								Name:     "name",
+								Type:     "text",
+								Position: 2,
+								NotNull:  true,
 							},
 						},
 					},
@@ -65,34 +71,336 @@ CREATE TABLE public.posts (
 				require.NoError(t, err)
 				require.Equal(t, map[string]Table{
 					"users": {
-						Name: "users",
+						Name:   "users",
+						Schema: "public",
 						Columns: map[string]Column{
 							"id": {
-								Name: "id",
-								Type: "pg_catalog.int4",
+								Name:     "id",
+								Type:     "pg_catalog.int4",
+								Position: 1,
+								NotNull:  true,
 							},
 							"name": {
-								Name: "name",
-								Type: "text",
+								Name:     "name",
+								Type:     "text",
+								Position: 2,
+								NotNull:  true,
 							},
 						},
 					},
 					"posts": {
-						Name: "posts",
+						Name:   "posts",
+						Schema: "public",
 						Columns: map[string]Column{
 							"id": {
-								Name: "id",
-								Type: "pg_catalog.int4",
+								Name:     "id",
+								Type:     "pg_catalog.int4",
+								Position: 1,
+								NotNull:  true,
 							},
 							"title": {
-								Name: "title",
-								Type: "text",
+								Name:     "title",
+								Type:     "text",
+								Position: 2,
+								NotNull:  true,
 							},
 						},
 					},
 				}, res)
 			},
 		},
+		{
+			name: "array column",
+			schemaInput: `
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    tags text[] NOT NULL
+);
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "pg_catalog.int4", res["users"].Columns["id"].Type)
+				require.Equal(t, "text[]", res["users"].Columns["tags"].Type)
+			},
+		},
+		{
+			name: "column constraints",
+			schemaInput: `
+CREATE TABLE public.users (
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    seq serial,
+    ext_id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text NOT NULL,
+    status text DEFAULT 'active' NOT NULL,
+    nickname text NULL,
+    name_upper text GENERATED ALWAYS AS (upper(name)) STORED,
+    email text,
+    PRIMARY KEY (email)
+);
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				require.Equal(t, map[string]Column{
+					"id": {
+						Name: "id", Type: "pg_catalog.int8", Position: 1,
+						NotNull: true, HasDefault: true, Identity: true, GeneratedAlways: true,
+					},
+					"seq": {
+						Name: "seq", Type: "serial", Position: 2,
+						NotNull: true, HasDefault: true,
+					},
+					"ext_id": {
+						Name: "ext_id", Type: "pg_catalog.int4", Position: 3,
+						NotNull: true, HasDefault: true, Identity: true,
+					},
+					"name": {Name: "name", Type: "text", Position: 4, NotNull: true},
+					"status": {
+						Name: "status", Type: "text", Position: 5,
+						NotNull: true, HasDefault: true,
+					},
+					"nickname": {Name: "nickname", Type: "text", Position: 6},
+					"name_upper": {
+						Name: "name_upper", Type: "text", Position: 7,
+						HasDefault: true, GeneratedAlways: true,
+					},
+					"email": {Name: "email", Type: "text", Position: 8, NotNull: true},
+				}, res["users"].Columns)
+			},
+		},
+		{
+			name: "alter table",
+			schemaInput: `
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    name text,
+    legacy text
+);
+CREATE TABLE public.posts (
+    id integer
+);
+CREATE VIEW user_names AS SELECT name FROM users;
+
+ALTER TABLE ONLY public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE users ADD COLUMN email varchar NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email text;
+ALTER TABLE users DROP COLUMN legacy, DROP COLUMN IF EXISTS missing;
+ALTER TABLE users ALTER COLUMN id TYPE bigint;
+ALTER TABLE users ALTER COLUMN name SET NOT NULL;
+ALTER TABLE users ALTER COLUMN email DROP DEFAULT;
+ALTER TABLE users RENAME COLUMN name TO full_name;
+ALTER TABLE users RENAME TO accounts;
+ALTER TABLE unknown_seq OWNER TO postgres;
+DROP TABLE posts;
+CREATE OR REPLACE VIEW user_names AS SELECT full_name FROM accounts;
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				require.Equal(t, map[string]Table{
+					"accounts": {
+						Name:   "accounts",
+						Schema: "public",
+						Columns: map[string]Column{
+							"id":        {Name: "id", Type: "pg_catalog.int8", Position: 1, NotNull: true},
+							"full_name": {Name: "full_name", Type: "text", Position: 2, NotNull: true},
+							// legacy was dropped, positions are not reused
+							"email": {Name: "email", Type: "pg_catalog.varchar", Position: 4, NotNull: true},
+						},
+					},
+					"user_names": {
+						Name:   "user_names",
+						Schema: "public",
+						Columns: map[string]Column{
+							"full_name": {Name: "full_name", Position: 1},
+						},
+						ReadOnly: true,
+					},
+				}, res)
+			},
+		},
+		{
+			name: "alter unknown column",
+			schemaInput: `
+CREATE TABLE users (id integer);
+ALTER TABLE users RENAME COLUMN name TO full_name;
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.EqualError(t, err, "line 3: column `name` is not defined in table `users`")
+			},
+		},
+		{
+			name: "pg_dump output",
+			schemaInput: `
+\restrict abc123
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+\connect app
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    name text
+);
+ALTER TABLE public.users OWNER TO app;
+COMMENT ON TABLE public.users IS 'registered users';
+CREATE SEQUENCE public.users_id_seq AS integer START WITH 1 INCREMENT BY 1;
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+
+COPY public.users (id, name) FROM stdin;
+1	alice; DROP TABLE users;
+2	\N
+\.
+
+SELECT pg_catalog.setval('public.users_id_seq', 2, true);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+\unrestrict abc123
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				require.Equal(t, map[string]Table{
+					"users": {
+						Name:   "users",
+						Schema: "public",
+						Columns: map[string]Column{
+							"id":   {Name: "id", Type: "pg_catalog.int4", Position: 1, NotNull: true, HasDefault: true},
+							"name": {Name: "name", Type: "text", Position: 2},
+						},
+					},
+				}, res)
+			},
+		},
+		{
+			name: "syntax error line",
+			schemaInput: `
+CREATE TABLE users (id integer);
+
+-- broken statement
+CREATE TABLE posts (id integer,);
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.ErrorContains(t, err, "line 5: ")
+			},
+		},
+		{
+			name: "schemas and search path",
+			schemaInput: `
+CREATE SCHEMA auth;
+CREATE TABLE users (id integer);
+CREATE TABLE auth.users (id integer, password text);
+CREATE SCHEMA billing CREATE TABLE invoices (id integer);
+SET search_path TO auth, public;
+CREATE TABLE sessions (id integer);
+ALTER TABLE users ADD COLUMN email text;
+DROP SCHEMA billing CASCADE;
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				tables := []string{}
+				for key := range res {
+					tables = append(tables, key)
+				}
+				require.ElementsMatch(t, []string{"users", "auth.users", "auth.sessions"}, tables)
+				require.Equal(t, "auth", res["auth.sessions"].Schema)
+				require.Contains(t, res["auth.users"].Columns, "email")
+				require.NotContains(t, res["users"].Columns, "email")
+			},
+		},
+		{
+			name: "enum types",
+			schemaInput: `
+CREATE SCHEMA auth;
+CREATE TYPE public.order_status AS ENUM ('pending', 'delivered');
+CREATE TYPE auth.role AS ENUM ('user', 'admin');
+CREATE TABLE orders (
+    id integer,
+    status order_status NOT NULL,
+    history order_status[],
+    role auth.role
+);
+ALTER TYPE order_status ADD VALUE 'shipped' BEFORE 'delivered';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'pending';
+ALTER TYPE order_status ADD VALUE 'returned';
+ALTER TYPE auth.role RENAME VALUE 'user' TO 'member';
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				cols := res["orders"].Columns
+				require.Equal(t, &Enum{
+					Name:   "order_status",
+					Schema: "public",
+					Values: []string{"pending", "shipped", "delivered", "returned"},
+				}, cols["status"].Enum)
+				require.Equal(t, &Enum{
+					Name:   "role",
+					Schema: "auth",
+					Values: []string{"member", "admin"},
+				}, cols["role"].Enum)
+				require.Nil(t, cols["history"].Enum)
+				require.Nil(t, cols["id"].Enum)
+			},
+		},
+		{
+			name: "partitions, inheritance and materialized views",
+			schemaInput: `
+CREATE TABLE events (
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    kind text NOT NULL,
+    created_at timestamptz DEFAULT now()
+) PARTITION BY RANGE (created_at);
+CREATE TABLE events_2024 PARTITION OF events (kind DEFAULT 'misc')
+    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+CREATE TABLE audit_events (actor text) INHERITS (events);
+CREATE TABLE events_copy (LIKE events, note text);
+CREATE TABLE events_backup (LIKE events INCLUDING ALL);
+CREATE MATERIALIZED VIEW event_counts (event_kind) AS
+    SELECT kind, count(*) FROM events GROUP BY kind;
+CREATE TABLE kinds AS SELECT DISTINCT kind FROM events;
+`,
+			testFunc: func(t *testing.T, res map[string]Table, err error) {
+				require.NoError(t, err)
+				id := Column{
+					Name: "id", Type: "pg_catalog.int8", Position: 1,
+					NotNull: true, HasDefault: true, Identity: true, GeneratedAlways: true,
+				}
+				plainID := Column{Name: "id", Type: "pg_catalog.int8", Position: 1, NotNull: true}
+				kind := Column{Name: "kind", Type: "text", Position: 2, NotNull: true}
+				createdAt := Column{Name: "created_at", Type: "timestamptz", Position: 3, HasDefault: true}
+
+				require.Equal(t, map[string]Column{
+					"id":         id,
+					"kind":       {Name: "kind", Type: "text", Position: 2, NotNull: true, HasDefault: true},
+					"created_at": createdAt,
+				}, res["events_2024"].Columns)
+				require.Equal(t, map[string]Column{
+					"id":         plainID,
+					"kind":       kind,
+					"created_at": createdAt,
+					"actor":      {Name: "actor", Type: "text", Position: 4},
+				}, res["audit_events"].Columns)
+				require.Equal(t, map[string]Column{
+					"id":         plainID,
+					"kind":       kind,
+					"created_at": {Name: "created_at", Type: "timestamptz", Position: 3},
+					"note":       {Name: "note", Type: "text", Position: 4},
+				}, res["events_copy"].Columns)
+				require.Equal(t, res["events"].Columns, res["events_backup"].Columns)
+
+				require.Equal(t, Table{
+					Name:   "event_counts",
+					Schema: "public",
+					Columns: map[string]Column{
+						"event_kind": {Name: "event_kind", Position: 1},
+						"count":      {Name: "count", Position: 2},
+					},
+					ReadOnly: true,
+				}, res["event_counts"])
+				require.Equal(t, Table{
+					Name:    "kinds",
+					Schema:  "public",
+					Columns: map[string]Column{"kind": {Name: "kind", Position: 1}},
+				}, res["kinds"])
+			},
+		},
 		{
 			name: "view",
 			schemaInput: `
@@ -105,28 +413,36 @@ CREATE VIEW public.users_posts AS
 				require.NoError(t, err)
 				require.Equal(t, map[string]Table{
 					"users_posts": {
-						Name: "users_posts",
+						Name:   "users_posts",
+						Schema: "public",
 						Columns: map[string]Column{
 							"user_id": {
-								Name: "user_id",
+								Name:     "user_id",
+								Position: 1,
 							},
 							"user_name": {
-								Name: "user_name",
+								Name:     "user_name",
+								Position: 2,
 							},
 							"user_property": {
-								Name: "user_property",
+								Name:     "user_property",
+								Position: 3,
 							},
 							"post_id": {
-								Name: "post_id",
+								Name:     "post_id",
+								Position: 4,
 							},
 							"title": {
-								Name: "title",
+								Name:     "title",
+								Position: 5,
 							},
 							"post_property": {
-								Name: "post_property",
+								Name:     "post_property",
+								Position: 6,
 							},
 							"count": {
-								Name: "count",
+								Name:     "count",
+								Position: 7,
 							},
 						},
 						ReadOnly: true,
@@ -146,3 +462,88 @@ CREATE VIEW public.users_posts AS
 		})
 	}
 }
+
+func Test_parsePostgresFunctions(t *testing.T) {
+	loader := newPgSchemaLoader()
+	err := loader.apply(`
+CREATE SCHEMA auth;
+CREATE FUNCTION slugify(value text, sep text DEFAULT '-') RETURNS text AS $$ SELECT $1 $$ LANGUAGE sql;
+CREATE OR REPLACE FUNCTION slugify(value text, sep text DEFAULT '-') RETURNS text AS $$ SELECT $1 $$ LANGUAGE sql;
+CREATE FUNCTION auth.has_scope(uid int, scope text, OUT granted bool) AS $$ SELECT true $$ LANGUAGE sql;
+CREATE FUNCTION join_all(sep text, VARIADIC parts text[]) RETURNS text AS $$ SELECT $1 $$ LANGUAGE sql;
+CREATE PROCEDURE cleanup() AS $$ SELECT 1 $$ LANGUAGE sql;
+CREATE AGGREGATE product(numeric) (SFUNC = numeric_mul, STYPE = numeric);
+`)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]Function{
+		"slugify":        {{Name: "slugify", Schema: "public", MinArgs: 1, MaxArgs: 2}},
+		"auth.has_scope": {{Name: "has_scope", Schema: "auth", MinArgs: 2, MaxArgs: 2}},
+		"join_all":       {{Name: "join_all", Schema: "public", MinArgs: 2, MaxArgs: -1}},
+		"product":        {{Name: "product", Schema: "public", MinArgs: 1, MaxArgs: 1}},
+	}, loader.functions)
+
+	overloads, ok := LookupFunction(loader.functions, nil, "", "count")
+	require.True(t, ok)
+	require.Equal(t, []Function{{Name: "count", Schema: "pg_catalog", MinArgs: 0, MaxArgs: 1}}, overloads)
+
+	_, ok = LookupFunction(loader.functions, nil, "", "has_scope")
+	require.False(t, ok)
+	_, ok = LookupFunction(loader.functions, []string{"auth"}, "", "has_scope")
+	require.True(t, ok)
+
+	overloads, ok = LookupFunction(loader.functions, nil, "pg_catalog", "pg_notify")
+	require.True(t, ok)
+	require.Equal(t, []Function{{Name: "pg_notify", Schema: "pg_catalog", MinArgs: 0, MaxArgs: -1}}, overloads)
+
+	// argument types of overloads are not part of function names
+	_, ok = LookupFunction(loader.functions, nil, "", "generate_series")
+	require.True(t, ok)
+	for _, name := range []string{"lower_text", "sum_int4", "count_any", "age_timestamp_timestamp", "generate_series_int4_int4"} {
+		_, ok = LookupFunction(loader.functions, nil, "", name)
+		require.False(t, ok, name)
+	}
+}
+
+func Test_parsePostgresExtensions(t *testing.T) {
+	loader := newPgSchemaLoader()
+	err := loader.apply(`
+CREATE EXTENSION IF NOT EXISTS fuzzystrmatch;
+CREATE SCHEMA ext CREATE TABLE t (id int);
+CREATE EXTENSION pg_trgm SCHEMA ext;
+`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fuzzystrmatch", "pg_trgm"}, loader.extensions)
+}
+
+func Test_viewColumnsResolver(t *testing.T) {
+	loader := newPgSchemaLoader()
+	relations := []string{}
+	loader.viewColumns = func(tables map[string]Table, searchPath []string, query *pg_query.Node) ([]string, bool) {
+		relations = append(relations, query.GetSelectStmt().GetFromClause()[0].GetRangeVar().GetRelname())
+		if _, ok := tables["users"]; !ok {
+			return nil, false
+		}
+		return []string{"id", "name"}, true
+	}
+
+	err := loader.apply(`
+CREATE VIEW early AS SELECT id, lower(name) FROM users;
+CREATE TABLE users (id integer, name text);
+CREATE VIEW user_names (user_id) AS SELECT * FROM users;
+CREATE SCHEMA reporting CREATE VIEW people AS SELECT * FROM users;
+`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"users", "users", "users"}, relations)
+	require.Equal(t, map[string]Column{
+		"id":    {Name: "id", Position: 1},
+		"lower": {Name: "lower", Position: 2},
+	}, loader.tables["early"].Columns)
+	require.Equal(t, map[string]Column{
+		"user_id": {Name: "user_id", Position: 1},
+		"name":    {Name: "name", Position: 2},
+	}, loader.tables["user_names"].Columns)
+	require.Equal(t, map[string]Column{
+		"id":   {Name: "id", Position: 1},
+		"name": {Name: "name", Position: 2},
+	}, loader.tables["reporting.people"].Columns)
+}
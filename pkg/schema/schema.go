@@ -1,24 +1,153 @@
 package schema
 
+import (
+	"sort"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+)
+
 // Column represents a column in table
 type Column struct {
 	Name string
 	Type string
+	// Position is the 1 based ordinal of the column in its table, `*`
+	// expands to columns in this order
+	Position int
+	// NotNull is set for NOT NULL and PRIMARY KEY columns
+	NotNull bool
+	// HasDefault is set for columns database fills in when they are omitted
+	// from INSERT: DEFAULT clause, serial types, identity and generated
+	// columns
+	HasDefault bool
+	// Identity is set for GENERATED ... AS IDENTITY columns
+	Identity bool
+	// GeneratedAlways is set for GENERATED ALWAYS identity and generated
+	// columns, these can only be written with DEFAULT
+	GeneratedAlways bool
+	// Enum is set for columns of ENUM type
+	Enum *Enum
+}
+
+// Enum represents an ENUM type created with CREATE TYPE ... AS ENUM
+type Enum struct {
+	Name   string
+	Schema string
+	// Values are enum labels in sort order
+	Values []string
+}
+
+// HasValue reports whether label is a valid value of the enum
+func (e *Enum) HasValue(label string) bool {
+	for _, v := range e.Values {
+		if v == label {
+			return true
+		}
+	}
+	return false
 }
 
 // Table represents a table in database
 type Table struct {
-	Name     string
+	Name string
+	// Schema is the namespace table belongs to, e.g. public
+	Schema   string
 	Columns  map[string]Column
 	ReadOnly bool
 }
 
+// Key returns key of the table in Db.Tables
+func (t Table) Key() string {
+	return TableKey(t.Schema, t.Name)
+}
+
+// ColumnNames returns names of the table columns in definition order
+func (t Table) ColumnNames() []string {
+	cols := make([]Column, 0, len(t.Columns))
+	for _, col := range t.Columns {
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool {
+		if cols[i].Position != cols[j].Position {
+			return cols[i].Position < cols[j].Position
+		}
+		return cols[i].Name < cols[j].Name
+	})
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// addColumn adds col after existing columns of the table
+func (t Table) addColumn(col Column) {
+	col.Position = 1
+	for _, c := range t.Columns {
+		if c.Position >= col.Position {
+			col.Position = c.Position + 1
+		}
+	}
+	t.Columns[col.Name] = col
+}
+
+// DefaultSearchPath is used to resolve unqualified table names unless a
+// search path is configured
+var DefaultSearchPath = []string{"public"}
+
+// TableKey returns key of a table in Db.Tables. Tables in public schema are
+// keyed by table name only, other tables by schema qualified name, e.g.
+// auth.users.
+func TableKey(schemaName string, name string) string {
+	if schemaName == "" || schemaName == "public" {
+		return name
+	}
+	return schemaName + "." + name
+}
+
+// LookupTable finds a table by its schema qualified name, unqualified names
+// are resolved through schemas in searchPath in order.
+func LookupTable(tables map[string]Table, searchPath []string, schemaName string, name string) (Table, bool) {
+	if schemaName != "" {
+		t, ok := tables[TableKey(schemaName, name)]
+		return t, ok
+	}
+	if len(searchPath) == 0 {
+		searchPath = DefaultSearchPath
+	}
+	for _, s := range searchPath {
+		if t, ok := tables[TableKey(s, name)]; ok {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// ViewColumnsFunc derives output columns of a view from tables defined
+// before it. query is the parsed query of the CREATE VIEW, CREATE
+// MATERIALIZED VIEW or CREATE TABLE AS statement, false is returned when
+// columns can't be resolved.
+type ViewColumnsFunc func(tables map[string]Table, searchPath []string, query *pg_query.Node) ([]string, bool)
+
 type Db struct {
 	Tables map[string]Table
+	// Functions holds functions and aggregates defined in schema by key of
+	// their qualified name, built-in ones are resolved by LookupFunction
+	Functions map[string][]Function
+	// Extensions lists extensions created in schema, functions they provide
+	// are not known
+	Extensions []string
+	// ViewColumns resolves view columns while loading schema, only names
+	// from top-level target list of view query are used when not set
+	ViewColumns ViewColumnsFunc
+	// Exclude lists files skipped in schema directories and glob matches,
+	// e.g. a migration inside a migrations directory applied separately
+	Exclude []string
 }
 
-func (s *Db) Load(schemaPath string) error {
-	return s.LoadPostgres(schemaPath)
+// Load loads and merges schema from files, migrations directories and glob
+// patterns
+func (s *Db) Load(schemaPaths ...string) error {
+	return s.LoadPostgres(schemaPaths...)
 }
 
 func NewDbSchema(schemaPath string) (*Db, error) {
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// expandSchemaPaths resolves schema files, migration directories and glob
+// patterns, e.g. db/schema/*.sql, into schema sources. Sources are returned
+// in the order of patterns, glob matches are sorted by name so the merged
+// schema doesn't depend on file system order. Glob matches in exclude are
+// skipped.
+func expandSchemaPaths(patterns []string, exclude map[string]bool) ([]string, error) {
+	paths := []string{}
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema path %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			if _, err := os.Stat(pattern); err != nil {
+				return nil, err
+			}
+			// file name containing glob meta characters
+			matches = []string{pattern}
+		}
+		for _, path := range matches {
+			path = filepath.Clean(path)
+			if seen[path] || (path != filepath.Clean(pattern) && exclude[absPath(path)]) {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// schemaFiles returns schema files in dir in lexical order, nil is returned
+// for migrations directories, i.e. ones with versioned migration files.
+func schemaFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		if _, ok := migrationVersion(name); ok {
+			return nil, nil
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
+
+// applySource applies schema file, migrations directory or directory of
+// schema files at path, tables created by it are tracked so conflicting
+// definitions in other sources can be reported.
+func (l *pgSchemaLoader) applySource(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		files, err := schemaFiles(path)
+		if err != nil {
+			return err
+		}
+		if files == nil {
+			l.source = path
+			l.searchPath = DefaultSearchPath
+			return l.applyMigrations(path)
+		}
+		for _, file := range files {
+			if l.exclude[absPath(file)] {
+				continue
+			}
+			if err := l.applySource(file); err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(file), err)
+			}
+		}
+		return nil
+	}
+
+	l.source = path
+	// each source runs in its own session
+	l.searchPath = DefaultSearchPath
+	schemaBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// a single goose migration only applies its up section
+	return l.apply(gooseUpSection(string(schemaBytes)))
+}
+
+// absPath returns cleaned absolute path, path is only cleaned if working
+// directory is not known
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// checkTableConflict reports a table created by current source that is
+// already defined differently by another source. Identical definitions, e.g.
+// the same table in a dump and a migration, are merged.
+func (l *pgSchemaLoader) checkTableConflict(table Table) error {
+	existing, ok := l.tables[table.Key()]
+	if !ok {
+		return nil
+	}
+	source := l.tableSources[table.Key()]
+	if source == "" || source == l.source || reflect.DeepEqual(existing, table) {
+		return nil
+	}
+	return fmt.Errorf("table `%s` is defined in both %s and %s", table.Key(), source, l.source)
+}
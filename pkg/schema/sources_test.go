@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSchemaPaths(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"b_posts.sql": "CREATE TABLE posts (id int, author_id int);",
+		"a_users.sql": "SET search_path TO auth;\nCREATE TABLE users (id int, name text);",
+		"c_views.sql": "CREATE VIEW post_authors AS SELECT p.id, p.author_id FROM posts p;",
+		"notes.txt":   "not a schema",
+	})
+	migrationsDir := filepath.Join(dir, "migrations")
+	require.NoError(t, os.Mkdir(migrationsDir, 0755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(migrationsDir, "001_comments.up.sql"),
+		[]byte("CREATE TABLE comments (id int);"), 0644))
+
+	paths, err := expandSchemaPaths([]string{
+		filepath.Join(dir, "*.sql"),
+		filepath.Join(dir, "b_posts.sql"),
+		migrationsDir,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "a_users.sql"),
+		filepath.Join(dir, "b_posts.sql"),
+		filepath.Join(dir, "c_views.sql"),
+		migrationsDir,
+	}, paths)
+
+	db := &Db{}
+	require.NoError(t, db.Load(filepath.Join(dir, "*.sql"), migrationsDir))
+	tables := []string{}
+	for key := range db.Tables {
+		tables = append(tables, key)
+	}
+	// search path set in one file doesn't leak into the next one
+	require.ElementsMatch(t, []string{"auth.users", "posts", "post_authors", "comments"}, tables)
+
+	_, err = expandSchemaPaths([]string{filepath.Join(dir, "missing.sql")}, nil)
+	require.Error(t, err)
+}
+
+func TestLoadSchemaDirectory(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"auth.sql":  "SET search_path TO auth;\nCREATE TABLE users (id int, name text);",
+		"posts.sql": "CREATE TABLE posts (id int, author_id int);",
+		"views.sql": "CREATE VIEW post_authors AS SELECT p.id, u.name FROM posts p JOIN auth.users u ON u.id = p.author_id;",
+		"notes.txt": "not a schema",
+	})
+
+	db := &Db{}
+	require.NoError(t, db.Load(dir))
+	tables := []string{}
+	for key := range db.Tables {
+		tables = append(tables, key)
+	}
+	require.ElementsMatch(t, []string{"auth.users", "posts", "post_authors"}, tables)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "z_broken.sql"), []byte("CREATE TABLE posts (id int, title text);"), 0644))
+	err := db.Load(dir)
+	require.EqualError(t, err, "z_broken.sql: line 1: table `posts` is defined in both "+
+		filepath.Join(dir, "posts.sql")+" and "+filepath.Join(dir, "z_broken.sql"))
+
+	db.Exclude = []string{filepath.Join(dir, "z_broken.sql")}
+	require.NoError(t, db.Load(dir))
+}
+
+func TestLoadSchemaPathsConflict(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"a.sql": "CREATE TABLE users (id int, name text);",
+		"b.sql": "CREATE TABLE users (id int, name text);",
+		"c.sql": "\nCREATE TABLE users (id int, email text);",
+	})
+
+	db := &Db{}
+	require.NoError(t, db.Load(filepath.Join(dir, "a.sql"), filepath.Join(dir, "b.sql")))
+
+	err := db.Load(filepath.Join(dir, "*.sql"))
+	require.EqualError(t, err, filepath.Join(dir, "c.sql")+": line 2: table `users` is defined in both "+
+		filepath.Join(dir, "a.sql")+" and "+filepath.Join(dir, "c.sql"))
+}
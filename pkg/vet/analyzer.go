@@ -2,28 +2,34 @@ package vet
 
 import (
 	"flag"
+	"fmt"
 	"go/ast"
 	"go/constant"
 	"go/token"
 	"go/types"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/houqp/sqlvet/pkg/config"
-	schema "github.com/houqp/sqlvet/pkg/schema"
+	"github.com/houqp/sqlvet/pkg/matcher"
 )
 
 // Analyzer implements a lightweight checker using go/analysis that inspects
 // call sites to common SQL APIs and validates constant query strings.
+// Functions forwarding their query parameter to a SQL API are recorded as
+// facts, so their callers get validated as well.
 // Note: Intentionally does not support string concatenation or non-constant
 // expressions per analyzer-mode limitations.
 var Analyzer = &analysis.Analyzer{
-	Name: "sqlvet",
-	Doc:  "Validate SQL query strings in calls to database/sql and sqlx APIs",
-	Run:  run,
+	Name:      "sqlvet",
+	Doc:       "Validate SQL query strings in calls to database/sql, sqlx and configured query functions",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(queryFuncFact)},
 }
 
 var (
@@ -32,75 +38,119 @@ var (
 
 func init() {
 	Analyzer.Flags.Init("sqlvet", flag.ContinueOnError)
-	Analyzer.Flags.StringVar(&configPathFlag, "f", "", "path to sqlvet.toml (defaults to ./sqlvet.toml)")
+	Analyzer.Flags.StringVar(&configPathFlag, "f", "", "path to sqlvet.toml (defaults to the nearest sqlvet.toml in package directory or its parents)")
+}
+
+// analyzerState holds config and schemas for a project, loaded lazily and
+// shared by all packages using the same sqlvet.toml.
+type analyzerState struct {
+	dbs      Databases
+	matchers []matcher.SqlFuncMatcher
+	// err is set when config or schema failed to load
+	err error
 }
 
-// analyzer state loaded lazily
 var (
-	analyzerSchemaLoaded bool
-	analyzerSchema       *Schema
+	analyzerStatesMu sync.Mutex
+	analyzerStates   = map[string]*analyzerState{}
 )
 
-// allowed packages to inspect, by import path
-var allowedPkgPaths = map[string]struct{}{
-	"database/sql":            {},
-	"github.com/jmoiron/sqlx": {},
+// findConfigPath resolves sqlvet.toml for the package being analyzed. Unless
+// specified through flag, config is searched from the package directory up to
+// the file system root.
+func findConfigPath(pass *analysis.Pass) string {
+	cfgPath := configPathFlag
+	if cfgPath != "" && filepath.IsAbs(cfgPath) {
+		return cfgPath
+	}
+	if len(pass.Files) == 0 {
+		return cfgPath
+	}
+	f := pass.Fset.File(pass.Files[0].Pos())
+	if f == nil {
+		return cfgPath
+	}
+	dir := filepath.Dir(f.Name())
+	if cfgPath != "" {
+		// Use first file to resolve relative path
+		return filepath.Join(dir, cfgPath)
+	}
+	for {
+		candidate := filepath.Join(dir, "sqlvet.toml")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func loadAnalyzerState(pass *analysis.Pass) *analyzerState {
+	cfgPath := findConfigPath(pass)
+
+	analyzerStatesMu.Lock()
+	defer analyzerStatesMu.Unlock()
+	if state, ok := analyzerStates[cfgPath]; ok {
+		return state
+	}
+
+	state := &analyzerState{
+		matchers: matcher.DefaultMatchers(),
+	}
+	if cfgPath != "" {
+		state.err = state.load(cfgPath)
+	}
+	analyzerStates[cfgPath] = state
+	return state
 }
 
-// function/method name to potential query argument positions
-// Positions are zero-based in CallExpr.Args (receiver is implicit for methods)
-var funcNameToQueryArgPositions = map[string][]int{
-	// database/sql DB methods
-	"Query":           {0},
-	"QueryRow":        {0},
-	"Exec":            {0},
-	"QueryContext":    {1},
-	"QueryRowContext": {1},
-	"ExecContext":     {1},
-
-	// sqlx DB methods
-	"NamedExec":         {0},
-	"NamedQuery":        {0},
-	"NamedExecContext":  {1},
-	"NamedQueryContext": {1},
-	// Select/Get take destination first
-	"Select":        {1},
-	"Get":           {1},
-	"SelectContext": {2},
-	"GetContext":    {2},
+func (s *analyzerState) load(cfgPath string) error {
+	cfg, err := config.Load(filepath.Dir(cfgPath))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", cfgPath, err)
+	}
+	s.matchers = cfg.Matchers()
+	s.dbs, err = LoadDatabases(filepath.Dir(cfgPath), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load schema for %s: %w", cfgPath, err)
+	}
+	return nil
 }
 
-func run(pass *analysis.Pass) (any, error) {
-	// Load config/schema once per process; analyzer runs per package
-	if !analyzerSchemaLoaded {
-		tables := map[string]schema.Table{}
-		// Resolve config path
-		cfgPath := configPathFlag
-		if cfgPath == "" {
-			cfgPath = "sqlvet.toml"
-		}
-		// Use first file to resolve relative path
-		if len(pass.Files) > 0 && !filepath.IsAbs(cfgPath) {
-			f := pass.Fset.File(pass.Files[0].Pos())
-			if f != nil {
-				cfgPath = filepath.Join(filepath.Dir(f.Name()), cfgPath)
-			}
+// matchQueryFunc returns the matcher of fn and position of the query
+// argument in CallExpr.Args if fn is one of the configured query functions.
+// Receiver is implicit for methods.
+func (s *analyzerState) matchQueryFunc(fn *types.Func) (*matcher.SqlFuncMatcher, int, bool) {
+	for i := range s.matchers {
+		if pos, ok := s.matchers[i].MatchFunc(fn); ok {
+			return &s.matchers[i], pos, true
 		}
-		cfg, err := config.Load(filepath.Dir(cfgPath))
-		if err == nil && cfg.SchemaPath != "" {
-			dbSchema, serr := schema.NewDbSchema(filepath.Join(filepath.Dir(cfgPath), cfg.SchemaPath))
-			if serr == nil {
-				tables = dbSchema.Tables
-			}
+	}
+	return nil, 0, false
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	// Config and schema are loaded once per project, analyzer runs per package
+	state := loadAnalyzerState(pass)
+	if state.err != nil {
+		// queries can't be validated without schema, report once per
+		// package instead of skipping them silently
+		if len(pass.Files) > 0 {
+			pass.Reportf(pass.Files[0].Package, "%v", state.err)
 		}
-		analyzerSchema = &Schema{Tables: tables}
-		analyzerSchemaLoaded = true
+		return nil, nil
 	}
 
+	exportQueryFuncFacts(pass, state)
+
 	// Build ignore comment ranges
 	ignoreNodes := collectIgnoreCommentNodes(pass)
 
 	for _, file := range pass.Files {
+		parents := parentMap(file)
 		ast.Inspect(file, func(n ast.Node) bool {
 			call, ok := n.(*ast.CallExpr)
 			if !ok {
@@ -110,38 +160,65 @@ func run(pass *analysis.Pass) (any, error) {
 				return true
 			}
 
-			name, pkgPath := resolveCallee(pass, call)
-			if name == "" || pkgPath == "" {
+			fn := resolveCallee(pass, call)
+			if fn == nil {
 				return true
 			}
-			if _, ok := allowedPkgPaths[pkgPath]; !ok {
+			qf, ok := lookupQueryFunc(pass, state, fn)
+			if !ok || qf.QueryArgPos >= len(call.Args) {
 				return true
 			}
-			positions, ok := funcNameToQueryArgPositions[name]
-			if !ok {
+
+			arg := call.Args[qf.QueryArgPos]
+			// Do not support string concatenation or non-constant expressions
+			if _, isBinary := arg.(*ast.BinaryExpr); isBinary {
+				return true
+			}
+			query, ok := constString(pass, arg)
+			if !ok || strings.TrimSpace(query) == "" {
 				return true
 			}
 
-			for _, idx := range positions {
-				if idx >= len(call.Args) {
-					continue
-				}
-				arg := call.Args[idx]
-				// Do not support string concatenation or non-constant expressions
-				if _, isBinary := arg.(*ast.BinaryExpr); isBinary {
-					continue
-				}
-				query, ok := constString(pass, arg)
-				if !ok || strings.TrimSpace(query) == "" {
-					continue
+			// Compile named queries and validate
+			qs := &QuerySite{Called: fn.Name(), Query: query, Named: qf.Named}
+			if qf.VariadicArgs && !call.Ellipsis.IsValid() {
+				// query parameters passed in as `args...` are not counted
+				qs.ParameterArgCount = len(call.Args) - qf.QueryArgPos - 1
+				qs.ParameterArgCountKnown = true
+				for _, paramArg := range call.Args[qf.QueryArgPos+1:] {
+					qs.ParameterArgTypes = append(qs.ParameterArgTypes, pass.TypesInfo.TypeOf(paramArg))
 				}
-
-				// Compile named queries and validate
-				qs := &QuerySite{Query: query}
-				handleQuery(NewContext(analyzerSchema.Tables), qs)
-				if qs.Err != nil {
-					reportPos := arg.Pos()
-					pass.Reportf(reportPos, "%v", qs.Err)
+			}
+			if qf.QueryArgPos > 0 {
+				destArg := call.Args[qf.QueryArgPos-1]
+				qs.DestType = sqlxDestType(fn.Pkg().Path(), fn.Name(), pass.TypesInfo.TypeOf(destArg))
+			}
+			if qf.Named && len(call.Args) > qf.QueryArgPos+1 {
+				namedArg := call.Args[qf.QueryArgPos+1]
+				qs.NamedArgType = sqlxNamedArgType(fn.Pkg().Path(), pass.TypesInfo.TypeOf(namedArg))
+				qs.NamedArgKeys = mapLiteralKeys(pass, namedArg)
+			}
+			scans := findScanCalls(pass, parents, call)
+			for _, scan := range scans {
+				qs.Scans = append(qs.Scans, &ScanSite{
+					Position:  pass.Fset.Position(scan.Lparen),
+					DestCount: len(scan.Args),
+				})
+			}
+			// wrappers are bound by the package they are defined in
+			pkgPath := pass.Pkg.Path()
+			if qf.Package != "" {
+				pkgPath = qf.Package
+			}
+			db := state.dbs.resolve(qf.Database, receiverTypes(pass, call), pkgPath)
+			handleQuery(newSchemaContext(db.Schema), qs)
+			if qs.Err != nil {
+				reportPos := arg.Pos()
+				pass.Reportf(reportPos, "%v", qs.Err)
+			}
+			for i, scan := range qs.Scans {
+				if scan.Err != nil {
+					pass.Reportf(scans[i].Lparen, "%v", scan.Err)
 				}
 			}
 
@@ -151,29 +228,42 @@ func run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
-func resolveCallee(pass *analysis.Pass, call *ast.CallExpr) (name string, pkgPath string) {
+func resolveCallee(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
 	switch fun := call.Fun.(type) {
 	case *ast.SelectorExpr:
 		// Method or qualified function call
 		if sel := pass.TypesInfo.Selections[fun]; sel != nil {
 			// method on a type
-			if fn, ok := sel.Obj().(*types.Func); ok {
-				return fn.Name(), fn.Pkg().Path()
+			if fn, ok := sel.Obj().(*types.Func); ok && fn.Pkg() != nil {
+				return fn
 			}
 		}
 		if obj, ok := pass.TypesInfo.Uses[fun.Sel]; ok {
 			if fn, ok := obj.(*types.Func); ok && fn.Pkg() != nil {
-				return fn.Name(), fn.Pkg().Path()
+				return fn
 			}
 		}
 	case *ast.Ident:
 		if obj, ok := pass.TypesInfo.Uses[fun]; ok {
 			if fn, ok := obj.(*types.Func); ok && fn.Pkg() != nil {
-				return fn.Name(), fn.Pkg().Path()
+				return fn
 			}
 		}
 	}
-	return "", ""
+	return nil
+}
+
+// receiverTypes returns type of the value a method is called on, nil for
+// function calls
+func receiverTypes(pass *analysis.Pass, call *ast.CallExpr) []types.Type {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if sel := pass.TypesInfo.Selections[fun]; sel == nil || sel.Kind() != types.MethodVal {
+		return nil
+	}
+	return []types.Type{pass.TypesInfo.TypeOf(fun.X)}
 }
 
 func constString(pass *analysis.Pass, e ast.Expr) (string, bool) {
@@ -184,6 +274,31 @@ func constString(pass *analysis.Pass, e ast.Expr) (string, bool) {
 	return constant.StringVal(tv.Value), true
 }
 
+// mapLiteralKeys returns keys of a map literal, nil if e is not a map
+// literal with constant keys.
+func mapLiteralKeys(pass *analysis.Pass, e ast.Expr) []string {
+	lit, ok := ast.Unparen(e).(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	if _, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Map); !ok {
+		return nil
+	}
+	keys := []string{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil
+		}
+		key, ok := constString(pass, kv.Key)
+		if !ok {
+			return nil
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 func collectIgnoreCommentNodes(pass *analysis.Pass) []ast.Node {
 	var nodes []ast.Node
 	for _, f := range pass.Files {
@@ -0,0 +1,45 @@
+package vet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/houqp/sqlvet/pkg/vet"
+)
+
+func TestAnalyzerQueryWrapper(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "wrapper/repo", "wrapper/app")
+}
+
+func TestAnalyzerQueryParams(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "params")
+}
+
+func TestAnalyzerScan(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "scan")
+}
+
+func TestAnalyzerSqlxDest(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "dest")
+}
+
+func TestAnalyzerParamTypes(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "paramtype")
+}
+
+func TestAnalyzerSqlxNamedArg(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "named")
+}
+
+func TestAnalyzerMultipleDatabases(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "multidb/billing", "multidb/app")
+}
+
+func TestAnalyzerSqlxBindHelpers(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "rebind")
+}
+
+func TestAnalyzerConfigError(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), vet.Analyzer, "badconfig")
+}
@@ -0,0 +1,313 @@
+package vet
+
+import (
+	"fmt"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// relation is a relation in FROM clause that can be expanded by `*`
+type relation struct {
+	// alias if present, table name otherwise
+	Name    string
+	Columns []string
+}
+
+// outputColumns returns names of columns in rows returned by stmt, false if
+// they can't be determined, e.g. `SELECT *` on a table that's not in the
+// schema. Unnamed expressions are named the same way as postgres does.
+func outputColumns(ctx VetContext, stmt *pg_query.Node) ([]string, bool) {
+	return stmtColumns(ctx, stmt, ctx.cteColumns())
+}
+
+// ctes maps CTE names to their columns, nil means columns are unknown
+func stmtColumns(ctx VetContext, stmt *pg_query.Node, ctes map[string][]string) ([]string, bool) {
+	switch n := stmt.GetNode().(type) {
+	case *pg_query.Node_SelectStmt:
+		return selectColumns(ctx, n.SelectStmt, ctes)
+	case *pg_query.Node_InsertStmt:
+		return returningColumns(ctx, n.InsertStmt.GetRelation(), n.InsertStmt.GetReturningList(), ctes)
+	case *pg_query.Node_UpdateStmt:
+		return returningColumns(ctx, n.UpdateStmt.GetRelation(), n.UpdateStmt.GetReturningList(), ctes)
+	case *pg_query.Node_DeleteStmt:
+		return returningColumns(ctx, n.DeleteStmt.GetRelation(), n.DeleteStmt.GetReturningList(), ctes)
+	}
+	return nil, false
+}
+
+func returningColumns(ctx VetContext, rv *pg_query.RangeVar, returningList []*pg_query.Node, ctes map[string][]string) ([]string, bool) {
+	rels, ok := rangeVarColumns(ctx, rv, ctes)
+	if !ok {
+		rels = nil
+	}
+	return targetListColumns(returningList, rels, nil)
+}
+
+func selectColumns(ctx VetContext, sel *pg_query.SelectStmt, ctes map[string][]string) ([]string, bool) {
+	if sel == nil {
+		return nil, false
+	}
+
+	if with := sel.GetWithClause(); with != nil {
+		outer := ctes
+		ctes = map[string][]string{}
+		for name, cols := range outer {
+			ctes[name] = cols
+		}
+		for _, n := range with.GetCtes() {
+			cte := n.GetCommonTableExpr()
+			if cte == nil {
+				continue
+			}
+			name := cte.GetCtename()
+			// self references of recursive CTEs see columns as unknown
+			ctes[name] = nil
+			ctes[name] = cteColumns(ctx, cte, ctes)
+		}
+	}
+
+	// UNION, INTERSECT and EXCEPT return columns of their left side
+	if sel.GetLarg() != nil {
+		return selectColumns(ctx, sel.GetLarg(), ctes)
+	}
+
+	if vls := sel.GetValuesLists(); len(vls) > 0 {
+		cols := []string{}
+		for i := range vls[0].GetList().GetItems() {
+			cols = append(cols, fmt.Sprintf("column%d", i+1))
+		}
+		return cols, true
+	}
+
+	rels := []relation{}
+	merged := []string{}
+	for _, n := range sel.GetFromClause() {
+		r, m, ok := fromColumns(ctx, n, ctes)
+		if !ok {
+			rels = nil
+			break
+		}
+		rels = append(rels, r...)
+		merged = append(merged, m...)
+	}
+
+	return targetListColumns(sel.GetTargetList(), rels, merged)
+}
+
+// fromColumns returns relations in a FROM clause item and columns merged by
+// JOIN USING.
+func fromColumns(ctx VetContext, n *pg_query.Node, ctes map[string][]string) ([]relation, []string, bool) {
+	switch from := n.GetNode().(type) {
+	case *pg_query.Node_RangeVar:
+		rels, ok := rangeVarColumns(ctx, from.RangeVar, ctes)
+		return rels, nil, ok
+	case *pg_query.Node_JoinExpr:
+		je := from.JoinExpr
+		if je.GetIsNatural() || je.GetAlias() != nil {
+			return nil, nil, false
+		}
+		lrels, lmerged, ok := fromColumns(ctx, je.GetLarg(), ctes)
+		if !ok {
+			return nil, nil, false
+		}
+		rrels, rmerged, ok := fromColumns(ctx, je.GetRarg(), ctes)
+		if !ok {
+			return nil, nil, false
+		}
+		// outer USING columns come first in join output
+		merged := []string{}
+		for _, u := range je.GetUsingClause() {
+			merged = append(merged, u.GetString_().GetSval())
+		}
+		merged = append(merged, lmerged...)
+		merged = append(merged, rmerged...)
+		return append(lrels, rrels...), merged, true
+	case *pg_query.Node_RangeSubselect:
+		cols, ok := stmtColumns(ctx, from.RangeSubselect.GetSubquery(), ctes)
+		if !ok {
+			return nil, nil, false
+		}
+		alias := from.RangeSubselect.GetAlias()
+		return []relation{{Name: alias.GetAliasname(), Columns: aliasColumns(alias.GetColnames(), cols)}}, nil, true
+	}
+	return nil, nil, false
+}
+
+// cteColumns returns columns of a CTE, nil if they are unknown
+func cteColumns(ctx VetContext, cte *pg_query.CommonTableExpr, ctes map[string][]string) []string {
+	cols, ok := stmtColumns(ctx, cte.GetCtequery(), ctes)
+	if !ok {
+		cols = nil
+	}
+	return aliasColumns(cte.GetAliascolnames(), cols)
+}
+
+// aliasColumns renames cols with column aliases, e.g. `AS t(a, b)`. Columns
+// without an alias keep their names, aliases alone are used if cols are
+// unknown.
+func aliasColumns(names []*pg_query.Node, cols []string) []string {
+	if len(names) == 0 {
+		return cols
+	}
+	if cols == nil {
+		cols = make([]string, len(names))
+	} else {
+		cols = append([]string{}, cols...)
+	}
+	for i, n := range names {
+		if i < len(cols) {
+			cols[i] = n.GetString_().GetSval()
+		}
+	}
+	return cols
+}
+
+// rangeVarColumns returns the CTE or schema table rv refers to
+func rangeVarColumns(ctx VetContext, rv *pg_query.RangeVar, ctes map[string][]string) ([]relation, bool) {
+	t := rangeVarToTableUsed(rv)
+	name := t.Name
+	if t.Alias != "" {
+		name = t.Alias
+	}
+	if cols, ok := ctes[t.Name]; ok && t.Schema == "" {
+		if cols == nil {
+			return nil, false
+		}
+		return []relation{{Name: name, Columns: cols}}, true
+	}
+	table, ok := schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, t.Schema, t.Name)
+	if !ok {
+		return nil, false
+	}
+	return []relation{{Name: name, Columns: table.ColumnNames()}}, true
+}
+
+// targetListColumns returns columns from a SELECT target list or RETURNING
+// list, `*` is expanded using rels. Nil rels means relations are unknown and
+// `*` can't be expanded.
+func targetListColumns(targets []*pg_query.Node, rels []relation, merged []string) ([]string, bool) {
+	cols := []string{}
+	for _, n := range targets {
+		target := n.GetResTarget()
+		if name := target.GetName(); name != "" {
+			cols = append(cols, name)
+			continue
+		}
+		fields := target.GetVal().GetColumnRef().GetFields()
+		if len(fields) == 0 || fields[len(fields)-1].GetAStar() == nil {
+			cols = append(cols, exprColumnName(target.GetVal()))
+			continue
+		}
+		if rels == nil {
+			return nil, false
+		}
+
+		if len(fields) == 1 {
+			// columns merged by JOIN USING show up once, ahead of the
+			// rest, each merge joins two occurrences into one
+			skip := map[string]int{}
+			for _, m := range merged {
+				if skip[m] == 0 {
+					cols = append(cols, m)
+					skip[m]++
+				}
+				skip[m]++
+			}
+			for _, r := range rels {
+				for _, c := range r.Columns {
+					if skip[c] > 0 {
+						skip[c]--
+						continue
+					}
+					cols = append(cols, c)
+				}
+			}
+			continue
+		}
+
+		// table.* or schema.table.*
+		relName := fields[len(fields)-2].GetString_().GetSval()
+		found := false
+		for _, r := range rels {
+			if r.Name == relName {
+				cols = append(cols, r.Columns...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return cols, true
+}
+
+// exprColumnName names an unaliased output column, following postgres'
+// FigureColname.
+func exprColumnName(expr *pg_query.Node) string {
+	switch n := expr.GetNode().(type) {
+	case *pg_query.Node_ColumnRef:
+		fields := n.ColumnRef.GetFields()
+		if len(fields) > 0 {
+			if s := fields[len(fields)-1].GetString_(); s != nil {
+				return s.GetSval()
+			}
+		}
+	case *pg_query.Node_FuncCall:
+		names := n.FuncCall.GetFuncname()
+		if len(names) > 0 {
+			return names[len(names)-1].GetString_().GetSval()
+		}
+	case *pg_query.Node_TypeCast:
+		if name := exprColumnName(n.TypeCast.GetArg()); name != "?column?" {
+			return name
+		}
+		names := n.TypeCast.GetTypeName().GetNames()
+		if len(names) > 0 {
+			return names[len(names)-1].GetString_().GetSval()
+		}
+	case *pg_query.Node_CollateClause:
+		return exprColumnName(n.CollateClause.GetArg())
+	case *pg_query.Node_SubLink:
+		switch n.SubLink.GetSubLinkType() {
+		case pg_query.SubLinkType_EXISTS_SUBLINK:
+			return "exists"
+		case pg_query.SubLinkType_ARRAY_SUBLINK:
+			return "array"
+		case pg_query.SubLinkType_EXPR_SUBLINK:
+			targets := n.SubLink.GetSubselect().GetSelectStmt().GetTargetList()
+			if len(targets) == 1 {
+				target := targets[0].GetResTarget()
+				if name := target.GetName(); name != "" {
+					return name
+				}
+				return exprColumnName(target.GetVal())
+			}
+		}
+	case *pg_query.Node_AExpr:
+		if n.AExpr.GetKind() == pg_query.A_Expr_Kind_AEXPR_NULLIF {
+			return "nullif"
+		}
+	case *pg_query.Node_CoalesceExpr:
+		return "coalesce"
+	case *pg_query.Node_MinMaxExpr:
+		if n.MinMaxExpr.GetOp() == pg_query.MinMaxOp_IS_LEAST {
+			return "least"
+		}
+		return "greatest"
+	case *pg_query.Node_CaseExpr:
+		return "case"
+	case *pg_query.Node_AArrayExpr:
+		return "array"
+	case *pg_query.Node_RowExpr:
+		return "row"
+	case *pg_query.Node_GroupingFunc:
+		return "grouping"
+	case *pg_query.Node_SqlvalueFunction:
+		return strings.ToLower(strings.TrimPrefix(n.SqlvalueFunction.GetOp().String(), "SVFOP_"))
+	}
+	return "?column?"
+}
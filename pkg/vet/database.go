@@ -0,0 +1,142 @@
+package vet
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/houqp/sqlvet/pkg/config"
+)
+
+// Database is a named schema queries are bound to, see config.Database
+type Database struct {
+	Name   string
+	Schema Schema
+	// Packages are import path patterns, a trailing /... matches sub
+	// packages as well
+	Packages []string
+	// Receivers are types of values query methods are called on, e.g.
+	// *billing.DB
+	Receivers []string
+}
+
+// Databases picks the schema each query is validated against. A query is
+// bound to a named database through the matcher of the called query function
+// first, then by receiver type of the call and finally by package of the
+// caller. Queries not bound to any database are validated against Default.
+type Databases struct {
+	Default Schema
+	Named   []Database
+}
+
+// LoadDatabases loads default and named database schemas declared in cfg,
+// schema paths are relative to root.
+func LoadDatabases(root string, cfg config.Config) (Databases, error) {
+	dbs := Databases{}
+	if sources := cfg.SchemaSources(); len(sources) > 0 {
+		db, err := LoadSchema(joinPaths(root, sources)...)
+		if err != nil {
+			return dbs, err
+		}
+		dbs.Default = Schema{Tables: db.Tables, Functions: db.Functions, Extensions: db.Extensions, SearchPath: cfg.SearchPath}
+	}
+	for _, d := range cfg.Databases {
+		named := Database{Name: d.Name, Packages: d.Packages, Receivers: d.Receivers}
+		if sources := d.SchemaSources(); len(sources) > 0 {
+			db, err := LoadSchema(joinPaths(root, sources)...)
+			if err != nil {
+				return dbs, fmt.Errorf("database `%s`: %w", d.Name, err)
+			}
+			named.Schema = Schema{Tables: db.Tables, Functions: db.Functions, Extensions: db.Extensions, SearchPath: d.SearchPath}
+		}
+		dbs.Named = append(dbs.Named, named)
+	}
+	return dbs, nil
+}
+
+func joinPaths(root string, paths []string) []string {
+	joined := []string{}
+	for _, p := range paths {
+		joined = append(joined, filepath.Join(root, p))
+	}
+	return joined
+}
+
+// resolve returns database of a query, unnamed for the default schema.
+// matcherDb is the database of the matched query function, recvTypes are
+// types of the value the query method is called on, including values it is
+// embedded in, and pkgPath is the package of the caller.
+func (d Databases) resolve(matcherDb string, recvTypes []types.Type, pkgPath string) Database {
+	if matcherDb != "" {
+		for _, db := range d.Named {
+			if db.Name == matcherDb {
+				return db
+			}
+		}
+	}
+	for _, db := range d.Named {
+		for _, recv := range db.Receivers {
+			for _, t := range recvTypes {
+				if matchReceiverType(recv, t) {
+					return db
+				}
+			}
+		}
+	}
+	for _, db := range d.Named {
+		for _, pattern := range db.Packages {
+			if matchPackagePattern(pattern, pkgPath) {
+				return db
+			}
+		}
+	}
+	return Database{Schema: d.Default}
+}
+
+// matchReceiverType matches t against a type name qualified by either
+// package name, e.g. *billing.DB, or import path
+func matchReceiverType(name string, t types.Type) bool {
+	short := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+	return name == short || name == types.TypeString(t, nil)
+}
+
+// matchPackagePattern matches an import path against a pattern, patterns
+// ending with /... match sub packages and path.Match wildcards are allowed.
+func matchPackagePattern(pattern, pkgPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+	}
+	ok, _ := path.Match(pattern, pkgPath)
+	return ok
+}
+
+// ssaReceiverTypes returns type of a receiver value and types of the values
+// it was loaded from, so promoted methods, e.g. Query of *sql.DB embedded in
+// *billing.DB, can be bound by the embedding type.
+func ssaReceiverTypes(v ssa.Value) []types.Type {
+	recvTypes := []types.Type{}
+	for v != nil {
+		recvTypes = append(recvTypes, v.Type())
+		switch val := v.(type) {
+		case *ssa.UnOp:
+			if val.Op != token.MUL {
+				return recvTypes
+			}
+			v = val.X
+		case *ssa.FieldAddr:
+			v = val.X
+		case *ssa.Field:
+			v = val.X
+		case *ssa.MakeInterface:
+			v = val.X
+		default:
+			v = nil
+		}
+	}
+	return recvTypes
+}
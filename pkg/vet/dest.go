@@ -0,0 +1,195 @@
+package vet
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const sqlxPkgPath = "github.com/jmoiron/sqlx"
+
+// nested structs are not expected to go deeper than this, it also guards
+// against self referencing types
+const maxDestFieldDepth = 8
+
+// destField is a struct field sqlx maps columns into
+type destField struct {
+	// column name, nested fields are prefixed with parent path, e.g. user.id
+	Path      string
+	OmitEmpty bool
+	// embedded struct without db tag, its fields are mapped without prefix
+	Promoted bool
+	Children []*destField
+}
+
+// sqlxDestType returns the type rows are scanned into when dest is passed to
+// sqlx Get or Select, e.g. User for both Get(&user, ...) and
+// Select(&users, ...). Nil is returned for other functions.
+func sqlxDestType(pkgPath string, funcName string, dest types.Type) types.Type {
+	if pkgPath != sqlxPkgPath || dest == nil {
+		return nil
+	}
+	ptr, ok := dest.Underlying().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+	switch funcName {
+	case "Get", "GetContext":
+		return ptr.Elem()
+	case "Select", "SelectContext":
+		slice, ok := ptr.Elem().Underlying().(*types.Slice)
+		if !ok {
+			return nil
+		}
+		if elemPtr, ok := slice.Elem().Underlying().(*types.Pointer); ok {
+			return elemPtr.Elem()
+		}
+		return slice.Elem()
+	}
+	return nil
+}
+
+// isScannable mirrors sqlx: non-struct types, sql.Scanner implementations and
+// structs without exported fields are scanned directly instead of by column
+// names.
+func isScannable(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, "Scan")
+	if fn, ok := obj.(*types.Func); ok && fn.Type().(*types.Signature).Params().Len() == 1 {
+		return true
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return true
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Exported() {
+			return false
+		}
+	}
+	return true
+}
+
+// collectDestFields maps struct fields to column names the same way sqlx
+// does with `db` tags, untagged fields are mapped to lower cased field name.
+func collectDestFields(st *types.Struct, prefix string, depth int) []*destField {
+	fields := []*destField{}
+	if depth > maxDestFieldDepth {
+		return fields
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("db")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if !f.Exported() && !f.Embedded() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name())
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		df := &destField{Path: path}
+		for _, opt := range strings.Split(opts, ",") {
+			if opt == "omitempty" {
+				df.OmitEmpty = true
+			}
+		}
+
+		ft := f.Type()
+		if ptr, ok := ft.Underlying().(*types.Pointer); ok {
+			ft = ptr.Elem()
+		}
+		fst, isStruct := ft.Underlying().(*types.Struct)
+		if f.Embedded() {
+			childPrefix := path
+			if tag == "" {
+				df.Promoted = true
+				childPrefix = prefix
+			}
+			if isStruct && !isScannable(ft) {
+				df.Children = collectDestFields(fst, childPrefix, depth+1)
+			}
+		} else if isStruct && !isScannable(ft) {
+			df.Children = collectDestFields(fst, path, depth+1)
+		}
+		fields = append(fields, df)
+	}
+	return fields
+}
+
+func collectDestPaths(fields []*destField, paths map[string]bool) {
+	for _, f := range fields {
+		paths[f.Path] = true
+		collectDestPaths(f.Children, paths)
+	}
+}
+
+// findUnselectedDestField returns the first non-omitempty field that no
+// column is mapped into. Fields of a nested struct are only checked when
+// some of them are selected.
+func findUnselectedDestField(fields []*destField, cols []string) *destField {
+	for _, f := range fields {
+		if f.Promoted {
+			if u := findUnselectedDestField(f.Children, cols); u != nil {
+				return u
+			}
+			continue
+		}
+		if f.OmitEmpty {
+			continue
+		}
+		selected := false
+		nested := false
+		for _, col := range cols {
+			if col == f.Path {
+				selected = true
+			} else if strings.HasPrefix(col, f.Path+".") {
+				nested = true
+			}
+		}
+		if selected {
+			continue
+		}
+		if !nested {
+			return f
+		}
+		if u := findUnselectedDestField(f.Children, cols); u != nil {
+			return u
+		}
+	}
+	return nil
+}
+
+// validateDest checks columns returned by the query against the type sqlx
+// Get and Select scan rows into.
+func validateDest(t types.Type, cols []string) error {
+	typeName := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+
+	if isScannable(t) {
+		if len(cols) > 1 {
+			return fmt.Errorf("scannable dest type %s with >1 columns (%d) in result", typeName, len(cols))
+		}
+		return nil
+	}
+
+	fields := collectDestFields(t.Underlying().(*types.Struct), "", 0)
+	paths := map[string]bool{}
+	collectDestPaths(fields, paths)
+	for _, col := range cols {
+		if !paths[col] {
+			return fmt.Errorf("missing destination name %s in %s", col, typeName)
+		}
+	}
+
+	if f := findUnselectedDestField(fields, cols); f != nil {
+		return fmt.Errorf("field `%s` of %s is not selected by query", f.Path, typeName)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package vet
+
+import (
+	"fmt"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// validateFuncCall checks a called function exists in the built-in catalog or
+// the schema, and accepts the number of arguments passed in. Functions
+// qualified with a schema other than pg_catalog may come from extensions
+// sqlvet doesn't know about, only their argument count is checked. The same
+// goes for window function calls, user-defined window functions can only be
+// written in C and usually come from extensions as well. Unknown functions
+// are not reported at all once schema creates an extension.
+func validateFuncCall(ctx VetContext, fc *pg_query.FuncCall) error {
+	if ctx.Schema.Tables == nil {
+		return nil
+	}
+	if fc.GetFuncformat() == pg_query.CoercionForm_COERCE_SQL_SYNTAX {
+		// special SQL syntax, e.g. EXTRACT(... FROM ...) or TRIM(BOTH ...)
+		return nil
+	}
+
+	names := []string{}
+	for _, n := range fc.GetFuncname() {
+		names = append(names, n.GetString_().GetSval())
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	name := names[len(names)-1]
+	schemaName := ""
+	if len(names) > 1 {
+		schemaName = names[len(names)-2]
+	}
+	qualifiedName := strings.Join(names, ".")
+
+	overloads, ok := schema.LookupFunction(ctx.Schema.Functions, ctx.Schema.SearchPath, schemaName, name)
+	if !ok {
+		if schemaName != "" && schemaName != "pg_catalog" {
+			return nil
+		}
+		if fc.GetOver() != nil || len(ctx.Schema.Extensions) > 0 {
+			return nil
+		}
+		return fmt.Errorf("function `%s` does not exist", qualifiedName)
+	}
+	if fc.GetFuncVariadic() {
+		// VARIADIC array argument expands to unknown number of arguments
+		return nil
+	}
+
+	argCount := len(fc.GetArgs())
+	for _, f := range overloads {
+		if f.Accepts(argCount) {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"function `%s` expects %s, but received %d",
+		qualifiedName, describeArgCounts(overloads), argCount,
+	)
+}
+
+// describeArgCounts formats accepted argument counts of function overloads,
+// e.g. "1 argument", "2 to 3 arguments" or "at least 1 argument"
+func describeArgCounts(overloads []schema.Function) string {
+	ranges := []string{}
+	for _, f := range overloads {
+		var r string
+		switch {
+		case f.MaxArgs < 0:
+			r = fmt.Sprintf("at least %d", f.MinArgs)
+		case f.MinArgs == f.MaxArgs:
+			r = fmt.Sprintf("%d", f.MinArgs)
+		default:
+			r = fmt.Sprintf("%d to %d", f.MinArgs, f.MaxArgs)
+		}
+		ranges = append(ranges, r)
+	}
+
+	suffix := " arguments"
+	if len(overloads) == 1 && overloads[0].MinArgs == 1 && overloads[0].MaxArgs <= 1 {
+		suffix = " argument"
+	}
+	return strings.Join(ranges, " or ") + suffix
+}
@@ -21,6 +21,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/houqp/sqlvet/pkg/matcher"
 	"github.com/houqp/sqlvet/pkg/parseutil"
 )
 
@@ -31,195 +32,129 @@ var (
 )
 
 type QuerySite struct {
-	Called            string
-	Position          token.Position
-	Query             string
-	ParameterArgCount int
-	Err               error
+	Called   string
+	Position token.Position
+	Query    string
+	// Named is set for queries using sqlx style named parameters, e.g. :id
+	Named bool
+	// ParameterArgCount is only validated when ParameterArgCountKnown is set,
+	// count can't be determined when args are passed in as `args...`
+	ParameterArgCount      int
+	ParameterArgCountKnown bool
+	// ParameterArgTypes are static types of query parameter arguments, nil
+	// for arguments with unknown type
+	ParameterArgTypes []types.Type
+	// Scans are Scan calls on rows returned by the query function
+	Scans []*ScanSite
+	// DestType is the type rows are scanned into by sqlx Get and Select
+	DestType types.Type
+	// NamedArgType is the type sqlx binds named parameters from, keys are
+	// only set for map literals
+	NamedArgType types.Type
+	NamedArgKeys []string
+	// Database is name of the database query is validated against, empty
+	// for the default one
+	Database string
+	// Usage holds schema tables and columns referenced by the query, set once
+	// query passes schema validation
+	Usage *Usage
+	Err   error
 }
 
-type MatchedSqlFunc struct {
-	SSA         *ssa.Function
-	QueryArgPos int
+// isNamedQueryFunc reports whether a query function takes sqlx style named
+// queries, e.g. NamedExec, NamedQueryContext or PrepareNamed.
+func isNamedQueryFunc(name string) bool {
+	return strings.Contains(name, "Named")
 }
 
-type SqlFuncMatchRule struct {
-	FuncName string `toml:"func_name"`
-	// zero indexed
-	QueryArgPos  int    `toml:"query_arg_pos"`
-	QueryArgName string `toml:"query_arg_name"`
-}
-
-type SqlFuncMatcher struct {
-	PkgPath string             `toml:"pkg_path"`
-	Rules   []SqlFuncMatchRule `toml:"rules"`
-
-	pkg *packages.Package
-}
-
-func (s *SqlFuncMatcher) SetGoPackage(p *packages.Package) {
-	s.pkg = p
-}
-
-func (s *SqlFuncMatcher) PackageImported() bool {
-	return s.pkg != nil
-}
-
-func (s *SqlFuncMatcher) IterPackageExportedFuncs(cb func(*types.Func)) {
-	scope := s.pkg.Types.Scope()
-	for _, scopeName := range scope.Names() {
-		obj := scope.Lookup(scopeName)
-		if !obj.Exported() {
-			continue
-		}
-
-		fobj, ok := obj.(*types.Func)
-		if ok {
-			cb(fobj)
-		} else {
-			// check for exported struct methods
-			switch otype := obj.Type().(type) {
-			case *types.Signature:
-			case *types.Named:
-				for i := 0; i < otype.NumMethods(); i++ {
-					m := otype.Method(i)
-					if !m.Exported() {
-						continue
-					}
-					cb(m)
-				}
-			case *types.Basic:
-			default:
-				log.Debugf("Skipped pkg scope: %s (%s)", otype, reflect.TypeOf(otype))
-			}
+func handleQuery(ctx VetContext, qs *QuerySite) {
+	// named queries are validated in compiled form, qs.Query is kept as
+	// written so the query can be validated again
+	query := qs.Query
+	var names []string
+	if qs.Named {
+		query, names, qs.Err = parseutil.CompileNamedQuery(
+			[]byte(qs.Query), parseutil.BindType("postgres"))
+		if qs.Err != nil {
+			return
 		}
 	}
-}
-
-func (s *SqlFuncMatcher) MatchSqlFuncs(prog *ssa.Program) []MatchedSqlFunc {
-	sqlfuncs := []MatchedSqlFunc{}
-
-	s.IterPackageExportedFuncs(func(fobj *types.Func) {
-		for _, rule := range s.Rules {
-			if rule.FuncName != "" && fobj.Name() == rule.FuncName {
-				sqlfuncs = append(sqlfuncs, MatchedSqlFunc{
-					SSA:         prog.FuncValue(fobj),
-					QueryArgPos: rule.QueryArgPos,
-				})
-				// callable matched one rule, no need to go through the rest
-				break
-			}
-
-			if rule.QueryArgName != "" {
-				sigParams := fobj.Type().(*types.Signature).Params()
-				if sigParams.Len()-1 < rule.QueryArgPos {
-					continue
-				}
-				param := sigParams.At(rule.QueryArgPos)
-				if param.Name() != rule.QueryArgName {
-					continue
-				}
-				sqlfuncs = append(sqlfuncs, MatchedSqlFunc{
-					SSA:         prog.FuncValue(fobj),
-					QueryArgPos: rule.QueryArgPos,
-				})
-				// callable matched one rule, no need to go through the rest
-				break
-			}
-		}
-	})
 
-	return sqlfuncs
-}
+	vctx := newSchemaContext(ctx.Schema)
+	queryParams, stmt, err := validateSqlQueryStmt(vctx, query)
+	if err != nil {
+		qs.Err = err
+		return
+	}
+	qs.Usage = queryUsage(vctx, stmt)
 
-func handleQuery(ctx VetContext, qs *QuerySite) {
-	// TODO: apply named query resolution based on v.X type and v.Sel.Name
-	// e.g. for sqlx, only apply to NamedExec and NamedQuery
-	qs.Query, _, qs.Err = parseutil.CompileNamedQuery(
-		[]byte(qs.Query), parseutil.BindType("postgres"))
+	// query string is valid, now validate parameter args if exists
+	qs.Err = validateQueryParams(qs, queryParams, names)
 	if qs.Err != nil {
 		return
 	}
-
-	var queryParams []QueryParam
-	queryParams, qs.Err = ValidateSqlQuery(NewContext(ctx.Schema.Tables), qs.Query)
-
+	qs.Err = validateParamTypes(qs, queryParams)
+	if qs.Err != nil {
+		return
+	}
+	qs.Err = validateNamedArg(qs, names)
 	if qs.Err != nil {
 		return
 	}
 
-	// query string is valid, now validate parameter args if exists
-	if qs.ParameterArgCount < len(queryParams) {
-		// qs.Err = fmt.Errorf(
-		// 	"Query expects %d parameters, but received %d from function call",
-		// 	len(queryParams), qs.ParameterArgCount,
-		// )
+	if len(qs.Scans) == 0 && qs.DestType == nil {
+		return
+	}
+	cols, ok := outputColumns(vctx, stmt)
+	if !ok {
+		return
+	}
+	validateScans(qs, cols)
+	if qs.DestType != nil {
+		qs.Err = validateDest(qs.DestType, cols)
 	}
 }
 
-func getMatchers(extraMatchers []SqlFuncMatcher) []*SqlFuncMatcher {
-	matchers := []*SqlFuncMatcher{
-		{
-			PkgPath: "github.com/jmoiron/sqlx",
-			Rules: []SqlFuncMatchRule{
-				{QueryArgName: "query"},
-				{QueryArgName: "sql"},
-				// for methods with Context suffix
-				{QueryArgName: "query", QueryArgPos: 1},
-				{QueryArgName: "sql", QueryArgPos: 1},
-				{QueryArgName: "query", QueryArgPos: 2},
-				{QueryArgName: "sql", QueryArgPos: 2},
-			},
-		},
-		{
-			PkgPath: "database/sql",
-			Rules: []SqlFuncMatchRule{
-				{QueryArgName: "query"},
-				{QueryArgName: "sql"},
-				// for methods with Context suffix
-				{QueryArgName: "query", QueryArgPos: 1},
-				{QueryArgName: "sql", QueryArgPos: 1},
-			},
-		},
-		{
-			PkgPath: "github.com/jinzhu/gorm",
-			Rules: []SqlFuncMatchRule{
-				{QueryArgName: "sql"},
-			},
-		},
-		// TODO: xorm uses vararg, which is not supported yet
-		// &SqlFuncMatcher{
-		// 	PkgPath: "xorm.io/xorm",
-		// 	Rules: []SqlFuncMatchRule{
-		// 		{FuncName: "SQL"},
-		// 		{FuncName: "Sql"},
-		// 		{FuncName: "Exec"},
-		// 		{FuncName: "Query"},
-		// 		{FuncName: "QueryInterface"},
-		// 		{FuncName: "QueryString"},
-		// 		{FuncName: "QuerySliceString"},
-		// 	},
-		// },
-		{
-			PkgPath: "go-gorp/gorp",
-			Rules: []SqlFuncMatchRule{
-				{QueryArgName: "query"},
-			},
-		},
-		{
-			PkgPath: "gopkg.in/gorp.v1",
-			Rules: []SqlFuncMatchRule{
-				{QueryArgName: "query"},
-			},
-		},
-	}
-	if extraMatchers != nil {
-		for _, m := range extraMatchers {
-			tmpm := m
-			matchers = append(matchers, &tmpm)
+// validateQueryParams checks $N placeholders are numbered without gaps and
+// match the number of arguments supplied by the function call.
+func validateQueryParams(qs *QuerySite, queryParams []QueryParam, names []string) error {
+	if qs.Named {
+		// sqlx binds one argument for each named parameter, a mismatch means
+		// some of them ended up in string literals or comments
+		if len(names) != len(queryParams) {
+			return fmt.Errorf(
+				"query expects %d parameters, but %d are bound from named parameters",
+				len(queryParams), len(names),
+			)
 		}
+		return nil
+	}
+
+	// queryParams is sorted by number and deduplicated
+	for i, p := range queryParams {
+		if p.Number != int32(i+1) {
+			return fmt.Errorf("query parameter $%d is not used, parameters must be numbered without gaps", i+1)
+		}
+	}
+
+	if qs.ParameterArgCountKnown && qs.ParameterArgCount != len(queryParams) {
+		return fmt.Errorf(
+			"query expects %d parameters, but received %d from function call",
+			len(queryParams), qs.ParameterArgCount,
+		)
 	}
+	return nil
+}
 
+func getMatchers(sqlMatchers []matcher.SqlFuncMatcher) []*matcher.SqlFuncMatcher {
+	if sqlMatchers == nil {
+		sqlMatchers = matcher.DefaultMatchers()
+	}
+	matchers := []*matcher.SqlFuncMatcher{}
+	for _, m := range sqlMatchers {
+		tmpm := m
+		matchers = append(matchers, &tmpm)
+	}
 	return matchers
 }
 
@@ -323,6 +258,24 @@ func extractQueryStrFromSsaValue(argVal ssa.Value) (string, error) {
 	return queryStr, nil
 }
 
+// ssaVarArgCount returns number of arguments passed in as variadic argument,
+// false if they are passed in as `args...`.
+func ssaVarArgCount(v ssa.Value) (int, bool) {
+	switch args := v.(type) {
+	case *ssa.Const:
+		// nil slice, no argument passed in
+		return 0, true
+	case *ssa.Slice:
+		if t, ok := args.X.Type().(*types.Pointer); ok {
+			if e, ok := t.Elem().(*types.Array); ok {
+				// variadic arguments are packed into an array
+				return int(e.Len()), true
+			}
+		}
+	}
+	return 0, false
+}
+
 func shouldIgnoreNode(ignoreNodes []ast.Node, callSitePos token.Pos) bool {
 	if len(ignoreNodes) == 0 {
 		return false
@@ -345,7 +298,7 @@ func shouldIgnoreNode(ignoreNodes []ast.Node, callSitePos token.Pos) bool {
 	return false
 }
 
-func iterCallGraphNodeCallees(ctx VetContext, cgNode *callgraph.Node, prog *ssa.Program, sqlfunc MatchedSqlFunc, ignoreNodes []ast.Node) []*QuerySite {
+func iterCallGraphNodeCallees(dbs Databases, cgNode *callgraph.Node, prog *ssa.Program, sqlfunc matcher.MatchedSqlFunc, ignoreNodes []ast.Node) []*QuerySite {
 	queries := []*QuerySite{}
 
 	for _, inEdge := range cgNode.In {
@@ -367,12 +320,14 @@ func iterCallGraphNodeCallees(ctx VetContext, cgNode *callgraph.Node, prog *ssa.
 		callArgs := callSite.Common().Args
 
 		absArgPos := sqlfunc.QueryArgPos
+		var recvTypes []types.Type
 		if callSite.Common().IsInvoke() {
 			// interface method invocation.
 			// In this mode, Value is the interface value and Method is the
 			// interface's abstract method. Note: an abstract method may be
 			// shared by multiple interfaces due to embedding; Value.Type()
 			// provides the specific interface used for this call.
+			recvTypes = ssaReceiverTypes(callSite.Common().Value)
 		} else {
 			// "call" mode: when Method is nil (!IsInvoke), a CallCommon
 			// represents an ordinary function call of the value in Value,
@@ -382,6 +337,7 @@ func iterCallGraphNodeCallees(ctx VetContext, cgNode *callgraph.Node, prog *ssa.
 				// it's a struct method call, plus 1 to take receiver into
 				// account
 				absArgPos += 1
+				recvTypes = ssaReceiverTypes(callArgs[0])
 			}
 		}
 		queryArg := callArgs[absArgPos]
@@ -389,27 +345,31 @@ func iterCallGraphNodeCallees(ctx VetContext, cgNode *callgraph.Node, prog *ssa.
 		qs := &QuerySite{
 			Called:   inEdge.Callee.Func.Name(),
 			Position: callSitePosition,
+			Named:    isNamedQueryFunc(sqlfunc.SSA.Name()),
 			Err:      nil,
 		}
 
-		if len(callArgs) > absArgPos+1 {
-			// query function accepts query parameters
-			paramArg := callArgs[absArgPos+1]
-			// only support query param as variadic argument for now
-			switch params := paramArg.(type) {
-			case *ssa.Const:
-				// likely nil
-			case *ssa.Slice:
-				sliceType := params.X.Type()
-				switch t := sliceType.(type) {
-				case *types.Pointer:
-					elem := t.Elem()
-					switch e := elem.(type) {
-					case *types.Array:
-						// query parameters are passed in as vararg: an array
-						// of interface
-						qs.ParameterArgCount = int(e.Len())
-					}
+		sig := sqlfunc.SSA.Signature
+		if sig.Variadic() && sqlfunc.QueryArgPos == sig.Params().Len()-2 {
+			// query function accepts query parameters, only support query
+			// param as variadic argument for now
+			qs.ParameterArgCount, qs.ParameterArgCountKnown = ssaVarArgCount(callArgs[absArgPos+1])
+			qs.ParameterArgTypes = ssaVarArgTypes(callArgs[absArgPos+1])
+		}
+		if v := callSite.Value(); v != nil {
+			qs.Scans = ssaScanSites(prog, v)
+		}
+		if absArgPos > 0 && sqlfunc.SSA.Pkg != nil {
+			// dest is converted to interface{} before being passed in
+			if dest, ok := callArgs[absArgPos-1].(*ssa.MakeInterface); ok {
+				qs.DestType = sqlxDestType(sqlfunc.SSA.Pkg.Pkg.Path(), sqlfunc.SSA.Name(), dest.X.Type())
+			}
+		}
+		if qs.Named && len(callArgs) > absArgPos+1 && sqlfunc.SSA.Pkg != nil {
+			if arg, ok := callArgs[absArgPos+1].(*ssa.MakeInterface); ok {
+				qs.NamedArgType = sqlxNamedArgType(sqlfunc.SSA.Pkg.Pkg.Path(), arg.X.Type())
+				if keys, ok := ssaMapLiteralKeys(arg.X); ok {
+					qs.NamedArgKeys = keys
 				}
 			}
 		}
@@ -442,7 +402,9 @@ func iterCallGraphNodeCallees(ctx VetContext, cgNode *callgraph.Node, prog *ssa.
 		if qs.Query == "" {
 			continue
 		}
-		handleQuery(ctx, qs)
+		db := dbs.resolve(sqlfunc.Database, recvTypes, callerFunc.Pkg.Pkg.Path())
+		qs.Database = db.Name
+		handleQuery(VetContext{Schema: db.Schema}, qs)
 		queries = append(queries, qs)
 	}
 
@@ -487,7 +449,11 @@ func getSortedIgnoreNodes(pkgs []*packages.Package) []ast.Node {
 	return ignoreNodes
 }
 
-func CheckDir(ctx VetContext, dir, buildFlags string, extraMatchers []SqlFuncMatcher) ([]*QuerySite, error) {
+// CheckDir performs whole-program analysis on the go module at dir. Queries
+// are validated against schema of the database they are bound to in dbs.
+// Query functions are located through sqlMatchers, nil means
+// matcher.DefaultMatchers should be used.
+func CheckDir(dbs Databases, dir, buildFlags string, sqlMatchers []matcher.SqlFuncMatcher) ([]*QuerySite, error) {
 	_, err := os.Stat(filepath.Join(dir, "go.mod"))
 	if os.IsNotExist(err) {
 		return nil, errors.New("sqlvet only supports projects using go modules for now.")
@@ -503,17 +469,17 @@ func CheckDir(ctx VetContext, dir, buildFlags string, extraMatchers []SqlFuncMat
 	log.Debugf("Identified %d queries to ignore", len(ignoreNodes))
 
 	// check to see if loaded packages imported any package that matches our rules
-	matchers := getMatchers(extraMatchers)
+	matchers := getMatchers(sqlMatchers)
 	log.Debugf("Loaded %d matchers, checking imported SQL packages...", len(matchers))
-	for _, matcher := range matchers {
+	for _, m := range matchers {
 		for _, p := range pkgs {
-			v, ok := p.Imports[matcher.PkgPath]
+			v, ok := p.Imports[m.PkgPath]
 			if !ok {
 				continue
 			}
 			// package is imported by at least of the loaded packages
-			matcher.SetGoPackage(v)
-			log.Debugf("\t%s imported", matcher.PkgPath)
+			m.SetGoPackage(v)
+			log.Debugf("\t%s imported", m.PkgPath)
 			break
 		}
 	}
@@ -523,13 +489,13 @@ func CheckDir(ctx VetContext, dir, buildFlags string, extraMatchers []SqlFuncMat
 	prog.Build()
 
 	// find ssa.Function for matched sqlfuncs from program
-	sqlfuncs := []MatchedSqlFunc{}
-	for _, matcher := range matchers {
-		if !matcher.PackageImported() {
+	sqlfuncs := []matcher.MatchedSqlFunc{}
+	for _, m := range matchers {
+		if !m.PackageImported() {
 			// if package is not imported, then no sqlfunc should be matched
 			continue
 		}
-		sqlfuncs = append(sqlfuncs, matcher.MatchSqlFuncs(prog)...)
+		sqlfuncs = append(sqlfuncs, m.MatchSqlFuncs(prog)...)
 	}
 	log.Debugf("Matched %d sqlfuncs", len(sqlfuncs))
 
@@ -553,7 +519,7 @@ func CheckDir(ctx VetContext, dir, buildFlags string, extraMatchers []SqlFuncMat
 		cgNode := cg.CreateNode(sqlfunc.SSA)
 		queries = append(
 			queries,
-			iterCallGraphNodeCallees(ctx, cgNode, prog, sqlfunc, ignoreNodes)...)
+			iterCallGraphNodeCallees(dbs, cgNode, prog, sqlfunc, ignoreNodes)...)
 	}
 
 	return queries, nil
@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/houqp/sqlvet/pkg/schema"
 	"github.com/houqp/sqlvet/pkg/vet"
 )
 
@@ -61,7 +62,7 @@ func main() {
 `), 0644)
 	assert.NoError(t, err)
 
-	_, err = vet.CheckDir(vet.VetContext{}, dir, "", nil)
+	_, err = vet.CheckDir(vet.Databases{}, dir, "", nil)
 	assert.Error(t, err)
 }
 
@@ -105,7 +106,7 @@ func main() {
 	err := ioutil.WriteFile(fpath, source, 0644)
 	assert.NoError(t, err)
 
-	queries, err := vet.CheckDir(vet.VetContext{}, dir, "", nil)
+	queries, err := vet.CheckDir(vet.Databases{}, dir, "", nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(queries))
 }
@@ -162,7 +163,7 @@ func main() {
 	err := ioutil.WriteFile(fpath, source, 0644)
 	assert.NoError(t, err)
 
-	queries, err := vet.CheckDir(vet.VetContext{}, dir, "", nil)
+	queries, err := vet.CheckDir(vet.Databases{}, dir, "", nil)
 	if err != nil {
 		t.Fatalf("Failed to load package: %s", err.Error())
 		return
@@ -214,7 +215,7 @@ func main() {
 	os.Chdir(parentDir)
 	defer os.Chdir(cwd)
 
-	queries, err := vet.CheckDir(vet.VetContext{}, filepath.Base(dir), "", nil)
+	queries, err := vet.CheckDir(vet.Databases{}, filepath.Base(dir), "", nil)
 	if err != nil {
 		t.Fatalf("Failed to load package: %s", err.Error())
 		return
@@ -258,7 +259,7 @@ func main() {
 	err := ioutil.WriteFile(fpath, source, 0644)
 	assert.NoError(t, err)
 
-	queries, err := vet.CheckDir(vet.VetContext{}, dir, "", nil)
+	queries, err := vet.CheckDir(vet.Databases{}, dir, "", nil)
 	if err != nil {
 		t.Fatalf("Failed to load package: %s", err.Error())
 		return
@@ -285,6 +286,185 @@ func main() {
 	assert.Equal(t, 1, queries[3].ParameterArgCount)
 }
 
+func (s *GoSourceTests) SubTestQueryParamCount(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"GoSourceTmpDir"`
+}) {
+	dir := fixtures.TmpDir
+
+	source := []byte(`
+package main
+
+import (
+	"database/sql"
+)
+
+func query(db *sql.DB, args ...interface{}) {
+	db.Query("SELECT 1 FROM foo WHERE id=$1 AND value=$2", args...)
+}
+
+func main() {
+	db, _ := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/hello")
+
+	db.Query("SELECT 1 FROM foo WHERE id=$1 AND value=$2", 1)
+
+	db.Exec("UPDATE foo SET id = $1", 10, 11)
+
+	db.Query("SELECT 1 FROM foo WHERE id=$1")
+
+	db.Query("SELECT 1 FROM foo WHERE id=$1 AND value=$3", 1, 2, 3)
+
+	query(db, 1)
+}
+	`)
+
+	fpath := filepath.Join(dir, "main.go")
+	err := ioutil.WriteFile(fpath, source, 0644)
+	assert.NoError(t, err)
+
+	queries, err := vet.CheckDir(vet.Databases{}, dir, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to load package: %s", err.Error())
+		return
+	}
+	assert.Equal(t, 5, len(queries))
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].Position.Offset < queries[j].Position.Offset
+	})
+
+	// args passed in as spread are not counted
+	assert.NoError(t, queries[0].Err)
+	assert.False(t, queries[0].ParameterArgCountKnown)
+
+	assert.EqualError(t, queries[1].Err,
+		"query expects 2 parameters, but received 1 from function call")
+	assert.EqualError(t, queries[2].Err,
+		"query expects 1 parameters, but received 2 from function call")
+	assert.EqualError(t, queries[3].Err,
+		"query expects 1 parameters, but received 0 from function call")
+	assert.EqualError(t, queries[4].Err,
+		"query parameter $2 is not used, parameters must be numbered without gaps")
+}
+
+func (s *GoSourceTests) SubTestScan(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"GoSourceTmpDir"`
+}) {
+	dir := fixtures.TmpDir
+
+	source := []byte(`
+package main
+
+import (
+	"database/sql"
+)
+
+func main() {
+	db, _ := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/hello")
+
+	var id int
+	var value string
+	db.QueryRow("SELECT id, value FROM foo").Scan(&id, &value)
+
+	db.QueryRow("SELECT * FROM foo").Scan(&id)
+
+	rows, _ := db.Query("SELECT id FROM foo")
+	defer rows.Close()
+	for rows.Next() {
+		rows.Scan(&id, &value)
+	}
+}
+	`)
+
+	fpath := filepath.Join(dir, "main.go")
+	err := ioutil.WriteFile(fpath, source, 0644)
+	assert.NoError(t, err)
+
+	dbs := vet.Databases{Default: vet.Schema{Tables: map[string]schema.Table{
+		"foo": {
+			Name: "foo",
+			Columns: map[string]schema.Column{
+				"id":    {Name: "id", Type: "int"},
+				"value": {Name: "value", Type: "text"},
+			},
+		},
+	}}}
+	queries, err := vet.CheckDir(dbs, dir, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to load package: %s", err.Error())
+		return
+	}
+	assert.Equal(t, 3, len(queries))
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].Position.Offset < queries[j].Position.Offset
+	})
+
+	for _, q := range queries {
+		assert.NoError(t, q.Err)
+		assert.Equal(t, 1, len(q.Scans))
+	}
+
+	assert.NoError(t, queries[0].Scans[0].Err)
+	assert.EqualError(t, queries[1].Scans[0].Err,
+		"query returns 2 columns, but Scan received 1 destinations")
+	assert.EqualError(t, queries[2].Scans[0].Err,
+		"query returns 1 columns, but Scan received 2 destinations")
+}
+
+func (s *GoSourceTests) SubTestParamTypes(t *testing.T, fixtures struct {
+	TmpDir string `fixture:"GoSourceTmpDir"`
+}) {
+	dir := fixtures.TmpDir
+
+	source := []byte(`
+package main
+
+import (
+	"database/sql"
+)
+
+func main() {
+	db, _ := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/hello")
+
+	db.Query("SELECT value FROM foo WHERE id=$1", 1)
+
+	db.Query("SELECT value FROM foo WHERE id=$1", "1")
+
+	db.Exec("UPDATE foo SET value=$1 WHERE id=$2", 2, 1)
+
+	db.Query("SELECT value FROM foo WHERE id=$1::text", "1")
+}
+	`)
+
+	fpath := filepath.Join(dir, "main.go")
+	err := ioutil.WriteFile(fpath, source, 0644)
+	assert.NoError(t, err)
+
+	dbs := vet.Databases{Default: vet.Schema{Tables: map[string]schema.Table{
+		"foo": {
+			Name: "foo",
+			Columns: map[string]schema.Column{
+				"id":    {Name: "id", Type: "int"},
+				"value": {Name: "value", Type: "text"},
+			},
+		},
+	}}}
+	queries, err := vet.CheckDir(dbs, dir, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to load package: %s", err.Error())
+		return
+	}
+	assert.Equal(t, 4, len(queries))
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].Position.Offset < queries[j].Position.Offset
+	})
+
+	assert.NoError(t, queries[0].Err)
+	assert.EqualError(t, queries[1].Err,
+		"query parameter $1 expects int, but received string argument")
+	assert.EqualError(t, queries[2].Err,
+		"query parameter $1 expects text, but received int argument")
+	assert.NoError(t, queries[3].Err)
+}
+
 func (s *GoSourceTests) SubTestBuildFlags(t *testing.T, fixtures struct {
 	TmpDir string `fixture:"GoSourceTmpDir"`
 }) {
@@ -308,9 +488,9 @@ func main() {
 	err := ioutil.WriteFile(fpath, source, 0644)
 	assert.NoError(t, err)
 
-	_, err = vet.CheckDir(vet.VetContext{}, dir, "", nil)
+	_, err = vet.CheckDir(vet.Databases{}, dir, "", nil)
 	assert.Error(t, err)
 
-	_, err = vet.CheckDir(vet.VetContext{}, dir, "-tags myBuildTag", nil)
+	_, err = vet.CheckDir(vet.Databases{}, dir, "-tags myBuildTag", nil)
 	assert.NoError(t, err)
 }
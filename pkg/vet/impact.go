@@ -0,0 +1,115 @@
+package vet
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/houqp/sqlvet/pkg/config"
+)
+
+// LoadMigrated returns copies of dbs where schema of database dbName is
+// loaded without and with DDL from migrationPath applied on top, empty name
+// is the default database. Migration is left out of schema sources, e.g.
+// when it is already in the migrations directory, so it is applied at most
+// once. Schema paths from cfg are relative to root.
+func LoadMigrated(root string, cfg config.Config, dbs Databases, dbName, migrationPath string) (before, after Databases, err error) {
+	sources := cfg.SchemaSources()
+	searchPath := cfg.SearchPath
+	if dbName != "" {
+		found := false
+		for _, d := range cfg.Databases {
+			if d.Name == dbName {
+				sources, searchPath, found = d.SchemaSources(), d.SearchPath, true
+			}
+		}
+		if !found {
+			return dbs, dbs, fmt.Errorf("unknown database `%s`", dbName)
+		}
+	}
+	if len(sources) == 0 {
+		return dbs, dbs, fmt.Errorf("no schema specified for the database migration applies to")
+	}
+
+	migration, err := filepath.Abs(migrationPath)
+	if err != nil {
+		return dbs, dbs, err
+	}
+	paths := []string{}
+	for _, p := range joinPaths(root, sources) {
+		if abs, err := filepath.Abs(p); err != nil || abs != migration {
+			paths = append(paths, p)
+		}
+	}
+
+	current, err := loadSchema([]string{migration}, paths...)
+	if err != nil {
+		return dbs, dbs, err
+	}
+	migrated, err := loadSchema([]string{migration}, append(paths, migration)...)
+	if err != nil {
+		return dbs, dbs, err
+	}
+	before = withSchema(dbs, dbName, Schema{Tables: current.Tables, Functions: current.Functions, Extensions: current.Extensions, SearchPath: searchPath})
+	after = withSchema(dbs, dbName, Schema{Tables: migrated.Tables, Functions: migrated.Functions, Extensions: migrated.Extensions, SearchPath: searchPath})
+	return before, after, nil
+}
+
+// withSchema returns a copy of dbs with schema of database dbName replaced
+func withSchema(dbs Databases, dbName string, s Schema) Databases {
+	replaced := Databases{Default: dbs.Default, Named: append([]Database{}, dbs.Named...)}
+	if dbName == "" {
+		replaced.Default = s
+	}
+	for i := range replaced.Named {
+		if replaced.Named[i].Name == dbName {
+			replaced.Named[i].Schema = s
+		}
+	}
+	return replaced
+}
+
+// Impact validates queries again against schemas in after and returns the
+// ones that were valid but fail with the new schemas. Returned query sites
+// are copies with errors found against the new schemas.
+func Impact(queries []*QuerySite, after Databases) []*QuerySite {
+	broken := []*QuerySite{}
+	for _, q := range queries {
+		if q.Query == "" || q.Err != nil || hasScanErr(q) {
+			continue
+		}
+
+		qs := *q
+		qs.Err = nil
+		qs.Usage = nil
+		qs.Scans = []*ScanSite{}
+		for _, scan := range q.Scans {
+			s := *scan
+			s.Err = nil
+			qs.Scans = append(qs.Scans, &s)
+		}
+		handleQuery(VetContext{Schema: after.schema(q.Database)}, &qs)
+		if qs.Err != nil || hasScanErr(&qs) {
+			broken = append(broken, &qs)
+		}
+	}
+	return broken
+}
+
+// schema returns schema of the named database, default one for empty name
+func (d Databases) schema(name string) Schema {
+	for _, db := range d.Named {
+		if db.Name == name {
+			return db.Schema
+		}
+	}
+	return d.Default
+}
+
+func hasScanErr(qs *QuerySite) bool {
+	for _, scan := range qs.Scans {
+		if scan.Err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package vet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/houqp/sqlvet/pkg/config"
+	"github.com/houqp/sqlvet/pkg/vet"
+)
+
+func TestImpact(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "schema.sql"), []byte(`
+CREATE TABLE users (id integer, name text, email text);
+`), 0644))
+	migration := filepath.Join(root, "002_drop_email.sql")
+	require.NoError(t, os.WriteFile(migration, []byte(`-- +goose Up
+ALTER TABLE users DROP COLUMN email;
+ALTER TABLE users RENAME COLUMN name TO full_name;
+-- +goose Down
+ALTER TABLE users RENAME COLUMN full_name TO name;
+ALTER TABLE users ADD COLUMN email text;
+`), 0644))
+
+	cfg := config.Config{SchemaPath: "schema.sql"}
+	dbs, err := vet.LoadDatabases(root, cfg)
+	require.NoError(t, err)
+	_, after, err := vet.LoadMigrated(root, cfg, dbs, "", migration)
+	require.NoError(t, err)
+	require.Contains(t, after.Default.Tables["users"].Columns, "full_name")
+	require.Contains(t, dbs.Default.Tables["users"].Columns, "name")
+
+	queries := []*vet.QuerySite{
+		{Query: "SELECT id FROM users"},
+		{Query: "SELECT email FROM users WHERE id = $1"},
+		{Query: "UPDATE users SET name = :name WHERE id = :id", Named: true},
+		{Query: "SELECT nosuch FROM users", Err: os.ErrInvalid},
+	}
+	broken := vet.Impact(queries, after)
+	require.Len(t, broken, 2)
+	require.EqualError(t, broken[0].Err, "column `email` is not defined in table `users`")
+	require.EqualError(t, broken[1].Err, "column `name` is not defined in table `users`")
+	require.Equal(t, "UPDATE users SET name = :name WHERE id = :id", broken[1].Query)
+	require.NoError(t, queries[1].Err)
+
+	_, _, err = vet.LoadMigrated(root, cfg, dbs, "billing", migration)
+	require.EqualError(t, err, "unknown database `billing`")
+}
+
+func TestImpactMigrationInMigrationsDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "migrations")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "001_users.sql"), []byte(`
+CREATE TABLE users (id integer, name text);
+`), 0644))
+	migration := filepath.Join(dir, "002_add_email.sql")
+	require.NoError(t, os.WriteFile(migration, []byte(`
+ALTER TABLE users ADD COLUMN email text;
+`), 0644))
+
+	cfg := config.Config{SchemaPath: "migrations"}
+	dbs, err := vet.LoadDatabases(root, cfg)
+	require.NoError(t, err)
+	require.Contains(t, dbs.Default.Tables["users"].Columns, "email")
+
+	// paths are compared cleaned
+	before, after, err := vet.LoadMigrated(root, cfg, dbs, "", dir+"/../migrations/002_add_email.sql")
+	require.NoError(t, err)
+	require.NotContains(t, before.Default.Tables["users"].Columns, "email")
+	require.Contains(t, after.Default.Tables["users"].Columns, "email")
+}
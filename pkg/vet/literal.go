@@ -0,0 +1,238 @@
+package vet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// integerInRange reports whether a numeric literal fits in a signed integer of
+// given bits once rounded, numeric values are rounded half away from zero on
+// assignment to integer columns.
+func integerInRange(value string, bits int) bool {
+	f, ok := new(big.Float).SetPrec(256).SetString(value)
+	if !ok || f.IsInf() {
+		return false
+	}
+	half := big.NewFloat(0.5)
+	if f.Sign() < 0 {
+		half.Neg(half)
+	}
+	n, _ := f.Add(f, half).Int(nil)
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	return n.Cmp(limit) < 0 && n.Cmp(limit.Neg(limit)) >= 0
+}
+
+// sqlLiteral is a constant value from A_Const node
+type sqlLiteral struct {
+	Kind  string // ival, fval, sval, boolval or isnull
+	Value string
+}
+
+func (l *sqlLiteral) String() string {
+	if l.Kind == "sval" {
+		return "'" + l.Value + "'"
+	}
+	return l.Value
+}
+
+// constLiteral returns value of A_Const node
+func constLiteral(n *pg_query.Node) *sqlLiteral {
+	c := n.GetAConst()
+	if c == nil {
+		return nil
+	}
+	if c.GetIsnull() {
+		return &sqlLiteral{Kind: "isnull", Value: "NULL"}
+	}
+	switch val := c.GetVal().(type) {
+	case *pg_query.A_Const_Ival:
+		return &sqlLiteral{Kind: "ival", Value: strconv.Itoa(int(val.Ival.GetIval()))}
+	case *pg_query.A_Const_Fval:
+		return &sqlLiteral{Kind: "fval", Value: val.Fval.GetFval()}
+	case *pg_query.A_Const_Sval:
+		return &sqlLiteral{Kind: "sval", Value: val.Sval.GetSval()}
+	case *pg_query.A_Const_Boolval:
+		return &sqlLiteral{Kind: "boolval", Value: strconv.FormatBool(val.Boolval.GetBoolval())}
+	}
+	return nil
+}
+
+// integer column types and their bit size
+var sqlIntegerBits = map[string]int{
+	"int2": 16, "smallint": 16, "smallserial": 16, "serial2": 16,
+	"int4": 32, "int": 32, "integer": 32, "serial": 32, "serial4": 32,
+	"int8": 64, "bigint": 64, "bigserial": 64, "serial8": 64,
+}
+
+// validateLiteralType checks a literal value can be coerced into column
+// type the same way postgres does on assignment, and NULL is not written to
+// NOT NULL columns. Columns without type and types not listed here are not
+// type checked.
+func validateLiteralType(col schema.Column, value *pg_query.Node) error {
+	lit := constLiteral(value)
+	if lit == nil {
+		return nil
+	}
+	if lit.Kind == "isnull" {
+		if col.NotNull {
+			return fmt.Errorf("null value in column `%s` violates NOT NULL constraint", col.Name)
+		}
+		return nil
+	}
+	if col.Enum != nil {
+		return validateEnumLiteral(col, value)
+	}
+	if col.Type == "" {
+		return nil
+	}
+	typ := normalizeSqlType(col.Type)
+	invalid := fmt.Errorf("invalid value %s for column `%s` of type %s", lit, col.Name, typ)
+
+	switch sqlTypeCategory(typ) {
+	case sqlTypeInteger:
+		bits := sqlIntegerBits[typ]
+		outOfRange := fmt.Errorf("value %s is out of range for column `%s` of type %s", lit, col.Name, typ)
+		switch lit.Kind {
+		case "ival", "sval":
+			_, err := strconv.ParseInt(strings.TrimSpace(lit.Value), 10, bits)
+			if errors.Is(err, strconv.ErrRange) {
+				return outOfRange
+			} else if err != nil {
+				return invalid
+			}
+		case "fval":
+			// integers out of int4 range are parsed as float too, exact
+			// value is needed to check bigint bounds
+			if !integerInRange(lit.Value, bits) {
+				return outOfRange
+			}
+		default:
+			return invalid
+		}
+	case sqlTypeFloat, sqlTypeNumeric:
+		if lit.Kind == "boolval" {
+			return invalid
+		}
+		if lit.Kind == "sval" && !isSqlNumeric(lit.Value) {
+			return invalid
+		}
+	case sqlTypeBool:
+		if lit.Kind == "ival" || lit.Kind == "fval" {
+			return invalid
+		}
+		if lit.Kind == "sval" && !isSqlBool(lit.Value) {
+			return invalid
+		}
+	case sqlTypeUUID:
+		if lit.Kind != "sval" || !isSqlUUID(lit.Value) {
+			return invalid
+		}
+	case sqlTypeTime:
+		if lit.Kind == "ival" || lit.Kind == "fval" || lit.Kind == "boolval" {
+			return invalid
+		}
+		if (typ == "date" || strings.HasPrefix(typ, "timestamp")) && !isSqlDate(lit.Value) {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// validateEnumLiteral checks a literal written to or compared with an ENUM
+// column is one of the enum labels
+func validateEnumLiteral(col schema.Column, value *pg_query.Node) error {
+	lit := constLiteral(value)
+	if lit == nil || lit.Kind == "isnull" || col.Enum == nil {
+		return nil
+	}
+	if lit.Kind != "sval" || !col.Enum.HasValue(lit.Value) {
+		return fmt.Errorf("invalid value %s for column `%s` of type %s", lit, col.Name, col.Type)
+	}
+	return nil
+}
+
+func isSqlNumeric(s string) bool {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "nan", "infinity", "+infinity", "-infinity", "inf", "+inf", "-inf":
+		return true
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil || errors.Is(err, strconv.ErrRange)
+}
+
+// isSqlBool mirrors postgres parse_bool, unique prefixes of true, false,
+// yes and no are accepted as well.
+func isSqlBool(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return false
+	}
+	// "o" alone is ambiguous between on and off
+	switch s {
+	case "1", "0", "on", "off", "of":
+		return true
+	}
+	for _, word := range []string{"true", "false", "yes", "no"} {
+		if strings.HasPrefix(word, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSqlUUID accepts the formats postgres does: optional braces around 32 hex
+// digits, with hyphens allowed after any group of 4 digits.
+func isSqlUUID(s string) bool {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") {
+		if !strings.HasSuffix(s, "}") {
+			return false
+		}
+		s = s[1 : len(s)-1]
+	}
+	digits := 0
+	for i, r := range s {
+		switch {
+		case r == '-':
+			if digits == 0 || digits%4 != 0 || i == len(s)-1 || s[i-1] == '-' {
+				return false
+			}
+		case unicode.Is(unicode.ASCII_Hex_Digit, r):
+			digits++
+		default:
+			return false
+		}
+	}
+	return digits == 32
+}
+
+// special date values accepted by postgres
+var sqlDateKeywords = map[string]bool{
+	"epoch": true, "infinity": true, "-infinity": true, "now": true,
+	"today": true, "tomorrow": true, "yesterday": true, "allballs": true,
+}
+
+// isSqlDate checks ISO 8601 dates, e.g. 2024-02-30 or 2024-13-01 are
+// rejected. Postgres accepts many other date styles, those are only
+// rejected when they don't contain any digit.
+func isSqlDate(s string) bool {
+	s = strings.TrimSpace(s)
+	if sqlDateKeywords[strings.ToLower(s)] {
+		return true
+	}
+	if len(s) >= 10 && s[4] == '-' && s[7] == '-' && strings.IndexFunc(s[:4], func(r rune) bool { return r < '0' || r > '9' }) < 0 {
+		_, err := time.Parse("2006-01-02", s[:10])
+		return err == nil
+	}
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
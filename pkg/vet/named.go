@@ -0,0 +1,99 @@
+package vet
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// sqlxNamedArgType returns the type named parameters are bound from, e.g.
+// User for NamedExec(query, &user) as well as batch inserts with []User.
+// Nil is returned for functions outside of sqlx and types that can't be
+// checked statically.
+func sqlxNamedArgType(pkgPath string, arg types.Type) types.Type {
+	if pkgPath != sqlxPkgPath || arg == nil {
+		return nil
+	}
+	t := arg
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		t = u.Elem()
+	case *types.Array:
+		t = u.Elem()
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch t.Underlying().(type) {
+	case *types.Struct, *types.Map:
+		return t
+	}
+	return nil
+}
+
+// validateNamedArg checks named parameters of the query can be bound from
+// the argument. Maps can only be checked when keys are known from a literal.
+func validateNamedArg(qs *QuerySite, names []string) error {
+	if qs.NamedArgType == nil {
+		return nil
+	}
+	typeName := types.TypeString(qs.NamedArgType, func(p *types.Package) string { return p.Name() })
+
+	switch t := qs.NamedArgType.Underlying().(type) {
+	case *types.Map:
+		if qs.NamedArgKeys == nil {
+			return nil
+		}
+		keys := map[string]bool{}
+		for _, k := range qs.NamedArgKeys {
+			keys[k] = true
+		}
+		for _, name := range names {
+			if !keys[name] {
+				return fmt.Errorf("could not find name %s in %s literal", name, typeName)
+			}
+		}
+	case *types.Struct:
+		paths := map[string]bool{}
+		collectDestPaths(collectDestFields(t, "", 0), paths)
+		for _, name := range names {
+			if !paths[name] {
+				return fmt.Errorf("could not find name %s in %s", name, typeName)
+			}
+		}
+	}
+	return nil
+}
+
+// ssaMapLiteralKeys returns keys set on a map created in the same function,
+// false if any of the keys is not a constant string.
+func ssaMapLiteralKeys(v ssa.Value) ([]string, bool) {
+	mm, ok := v.(*ssa.MakeMap)
+	if !ok || mm.Referrers() == nil {
+		return nil, false
+	}
+	keys := []string{}
+	for _, instr := range *mm.Referrers() {
+		var update *ssa.MapUpdate
+		switch instr := instr.(type) {
+		case *ssa.MapUpdate:
+			update = instr
+		case *ssa.MakeInterface, *ssa.DebugRef:
+			continue
+		default:
+			// map could be modified elsewhere
+			return nil, false
+		}
+		k, ok := update.Key.(*ssa.Const)
+		if !ok || k.Value == nil || k.Value.Kind() != constant.String {
+			return nil, false
+		}
+		keys = append(keys, constant.StringVal(k.Value))
+	}
+	return keys, true
+}
@@ -0,0 +1,395 @@
+package vet
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// SQL type categories used to check Go arguments passed in for query
+// parameters
+const (
+	sqlTypeInteger = "integer"
+	sqlTypeFloat   = "float"
+	sqlTypeNumeric = "numeric"
+	sqlTypeBool    = "bool"
+	sqlTypeText    = "text"
+	sqlTypeBytes   = "bytes"
+	sqlTypeTime    = "time"
+	sqlTypeUUID    = "uuid"
+)
+
+// Go type categories
+const (
+	goTypeInteger = "integer"
+	goTypeFloat   = "float"
+	goTypeBool    = "bool"
+	goTypeString  = "string"
+	goTypeBytes   = "bytes"
+	goTypeTime    = "time"
+)
+
+// Go types accepted by each SQL type category, categories missing from the
+// table are not checked
+var compatibleGoTypes = map[string][]string{
+	sqlTypeInteger: {goTypeInteger},
+	sqlTypeFloat:   {goTypeInteger, goTypeFloat},
+	sqlTypeNumeric: {goTypeInteger, goTypeFloat, goTypeString},
+	sqlTypeBool:    {goTypeBool},
+	sqlTypeText:    {goTypeString, goTypeBytes},
+	sqlTypeBytes:   {goTypeBytes, goTypeString},
+	sqlTypeTime:    {goTypeTime, goTypeString},
+	sqlTypeUUID:    {goTypeString, goTypeBytes},
+}
+
+// normalizeSqlType strips pg_catalog prefix from type names, e.g.
+// pg_catalog.int4 becomes int4.
+func normalizeSqlType(typ string) string {
+	return strings.TrimPrefix(strings.ToLower(typ), "pg_catalog.")
+}
+
+func sqlTypeCategory(typ string) string {
+	typ = normalizeSqlType(typ)
+	if strings.HasSuffix(typ, "[]") {
+		return ""
+	}
+	switch typ {
+	case "int2", "int4", "int8", "smallint", "int", "integer", "bigint",
+		"smallserial", "serial", "bigserial", "serial2", "serial4", "serial8":
+		return sqlTypeInteger
+	case "float4", "float8", "real", "double precision":
+		return sqlTypeFloat
+	case "numeric", "decimal":
+		return sqlTypeNumeric
+	case "bool", "boolean":
+		return sqlTypeBool
+	case "text", "varchar", "bpchar", "char", "character", "character varying", "citext", "name":
+		return sqlTypeText
+	case "bytea":
+		return sqlTypeBytes
+	case "timestamp", "timestamptz", "date", "time", "timetz":
+		return sqlTypeTime
+	case "uuid":
+		return sqlTypeUUID
+	}
+	return ""
+}
+
+// implementsValuer reports whether values of t convert themselves through
+// driver.Valuer, these are not checked.
+func implementsValuer(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Value")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 2
+}
+
+func goTypeCategory(t types.Type) string {
+	if t == nil || implementsValuer(t) {
+		return ""
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return goTypeCategory(ptr.Elem())
+	}
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+		return goTypeTime
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsInteger != 0:
+			return goTypeInteger
+		case info&types.IsFloat != 0:
+			return goTypeFloat
+		case info&types.IsBoolean != 0:
+			return goTypeBool
+		case info&types.IsString != 0:
+			return goTypeString
+		}
+	case *types.Slice:
+		if basic, ok := u.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return goTypeBytes
+		}
+	}
+	return ""
+}
+
+// validateParamTypes compares SQL types inferred for query parameters with
+// Go types of the arguments passed in.
+func validateParamTypes(qs *QuerySite, queryParams []QueryParam) error {
+	for _, p := range queryParams {
+		idx := int(p.Number) - 1
+		if p.Type == "" || idx < 0 || idx >= len(qs.ParameterArgTypes) {
+			continue
+		}
+		argType := qs.ParameterArgTypes[idx]
+		allowed, ok := compatibleGoTypes[sqlTypeCategory(p.Type)]
+		goCategory := goTypeCategory(argType)
+		if !ok || goCategory == "" {
+			continue
+		}
+		compatible := false
+		for _, c := range allowed {
+			if c == goCategory {
+				compatible = true
+				break
+			}
+		}
+		if !compatible {
+			typeName := types.TypeString(argType, func(p *types.Package) string { return p.Name() })
+			return fmt.Errorf(
+				"query parameter $%d expects %s, but received %s argument",
+				p.Number, normalizeSqlType(p.Type), typeName,
+			)
+		}
+	}
+	return nil
+}
+
+// ssaVarArgTypes returns static types of arguments passed in as variadic
+// argument, nil for unknown ones.
+func ssaVarArgTypes(v ssa.Value) []types.Type {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok || alloc.Referrers() == nil {
+		return nil
+	}
+	arr, ok := alloc.Type().(*types.Pointer).Elem().(*types.Array)
+	if !ok {
+		return nil
+	}
+	argTypes := make([]types.Type, arr.Len())
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok || addr.Referrers() == nil {
+			continue
+		}
+		idx, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		i := idx.Int64()
+		if i < 0 || i >= arr.Len() {
+			continue
+		}
+		for _, instr := range *addr.Referrers() {
+			store, ok := instr.(*ssa.Store)
+			if !ok || store.Addr != addr {
+				continue
+			}
+			if mi, ok := store.Val.(*ssa.MakeInterface); ok {
+				argTypes[i] = mi.X.Type()
+			}
+		}
+	}
+	return argTypes
+}
+
+// ---------------------- Placeholder type inference ----------------------
+
+// inferParamTypes sets Type of query params based on where they are used:
+// compared with a column, inserted into or updated as a column, or through
+// explicit type cast. Literals compared with ENUM columns are validated along
+// the way since their column is resolved the same way.
+func inferParamTypes(ctx VetContext, stmt *pg_query.Node, params []QueryParam) error {
+	inf := &paramTypeInferer{types: map[int32]string{}}
+	inf.walk(stmt, ctx)
+	if inf.err != nil {
+		return inf.err
+	}
+	for i := range params {
+		if t, ok := inf.types[params[i].Number]; ok {
+			params[i].Type = t
+		}
+	}
+	return nil
+}
+
+type paramTypeInferer struct {
+	types map[int32]string
+	// first invalid enum literal found in comparisons
+	err error
+}
+
+// setParamType records type for a ParamRef node, explicit casts override
+// types inferred from context.
+func (inf *paramTypeInferer) setParamType(n *pg_query.Node, typ string, override bool) {
+	param := n.GetParamRef()
+	if param == nil || typ == "" {
+		return
+	}
+	num := param.GetNumber()
+	if _, ok := inf.types[num]; ok && !override {
+		return
+	}
+	inf.types[num] = typ
+}
+
+// tableColumnType returns type of a column of INSERT, UPDATE or DELETE
+// target table
+func (inf *paramTypeInferer) tableColumnType(ctx VetContext, tu TableUsed, col string) string {
+	table, _ := schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, tu.Schema, tu.Name)
+	return table.Columns[col].Type
+}
+
+// column resolves a column reference through relations in scope
+func (inf *paramTypeInferer) column(ctx VetContext, n *pg_query.Node) (schema.Column, bool) {
+	cu := columnRefToColumnUsed(n.GetColumnRef())
+	if cu == nil {
+		return schema.Column{}, false
+	}
+	return ctx.resolveColumn(*cu)
+}
+
+func (inf *paramTypeInferer) inferFromTargets(ctx VetContext, table TableUsed, targets []*pg_query.Node) {
+	for _, n := range targets {
+		target := n.GetResTarget()
+		inf.setParamType(target.GetVal(), inf.tableColumnType(ctx, table, target.GetName()), false)
+	}
+}
+
+// enterStmt returns context for nodes nested in a statement, with CTEs and
+// FROM clause items of the statement in scope. Columns of CTEs and
+// subqueries are left unknown.
+func enterStmt(ctx VetContext, with *pg_query.WithClause, tables []TableUsed, from []*pg_query.Node) VetContext {
+	ctx = ctx.enterScope()
+	for _, n := range with.GetCtes() {
+		ctx.scope.ctes[n.GetCommonTableExpr().GetCtename()] = nil
+	}
+	subqueries := []relation{}
+	for _, n := range from {
+		tables, subqueries = fromRelations(n, tables, subqueries)
+	}
+	// statement is validated already, all tables can be resolved
+	_ = ctx.addRelations(tables, subqueries)
+	return ctx
+}
+
+// fromRelations appends tables and aliased subqueries of a FROM clause item
+func fromRelations(n *pg_query.Node, tables []TableUsed, subqueries []relation) ([]TableUsed, []relation) {
+	switch from := n.GetNode().(type) {
+	case *pg_query.Node_RangeVar:
+		tables = append(tables, rangeVarToTableUsed(from.RangeVar))
+	case *pg_query.Node_JoinExpr:
+		tables, subqueries = fromRelations(from.JoinExpr.GetLarg(), tables, subqueries)
+		tables, subqueries = fromRelations(from.JoinExpr.GetRarg(), tables, subqueries)
+	case *pg_query.Node_RangeSubselect:
+		if alias := from.RangeSubselect.GetAlias().GetAliasname(); alias != "" {
+			subqueries = append(subqueries, relation{Name: alias})
+		}
+	}
+	return tables, subqueries
+}
+
+// walk infers types from n and nodes nested in it, relations of a statement
+// are only in scope for nodes nested in the statement
+func (inf *paramTypeInferer) walk(n *pg_query.Node, ctx VetContext) {
+	switch node := n.GetNode().(type) {
+	case *pg_query.Node_SelectStmt:
+		sel := node.SelectStmt
+		ctx = enterStmt(ctx, sel.GetWithClause(), nil, sel.GetFromClause())
+		inf.setParamType(sel.GetLimitCount(), "int8", false)
+		inf.setParamType(sel.GetLimitOffset(), "int8", false)
+		if sel.GetOp() != pg_query.SetOperation_SETOP_NONE {
+			// arms of UNION, INTERSECT and EXCEPT are not nodes, walk them
+			// as statements of their own
+			for _, arm := range []*pg_query.SelectStmt{sel.GetLarg(), sel.GetRarg()} {
+				inf.walk(&pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: arm}}, ctx)
+			}
+			children := []*pg_query.Node{sel.GetLimitCount(), sel.GetLimitOffset()}
+			children = append(children, sel.GetWithClause().GetCtes()...)
+			for _, child := range append(children, sel.GetSortClause()...) {
+				inf.walk(child, ctx)
+			}
+			return
+		}
+	case *pg_query.Node_InsertStmt:
+		ins := node.InsertStmt
+		table := rangeVarToTableUsed(ins.GetRelation())
+		ctx = enterStmt(ctx, ins.GetWithClause(), []TableUsed{table}, nil)
+		cols := ins.GetCols()
+		for _, list := range ins.GetSelectStmt().GetSelectStmt().GetValuesLists() {
+			for i, item := range list.GetList().GetItems() {
+				if i < len(cols) {
+					name := cols[i].GetResTarget().GetName()
+					inf.setParamType(item, inf.tableColumnType(ctx, table, name), false)
+				}
+			}
+		}
+		inf.inferFromTargets(ctx, table, ins.GetOnConflictClause().GetTargetList())
+	case *pg_query.Node_UpdateStmt:
+		upd := node.UpdateStmt
+		table := rangeVarToTableUsed(upd.GetRelation())
+		ctx = enterStmt(ctx, upd.GetWithClause(), []TableUsed{table}, upd.GetFromClause())
+		inf.inferFromTargets(ctx, table, upd.GetTargetList())
+	case *pg_query.Node_DeleteStmt:
+		del := node.DeleteStmt
+		table := rangeVarToTableUsed(del.GetRelation())
+		ctx = enterStmt(ctx, del.GetWithClause(), []TableUsed{table}, del.GetUsingClause())
+	case *pg_query.Node_AExpr:
+		inf.inferFromComparison(ctx, node.AExpr)
+	case *pg_query.Node_TypeCast:
+		inf.setParamType(node.TypeCast.GetArg(), typeNameString(node.TypeCast.GetTypeName()), true)
+	}
+	walkChildren(n, func(child *pg_query.Node) bool {
+		inf.walk(child, ctx)
+		return false
+	})
+}
+
+// inferFromComparison handles `col = $1`, `$1 < col`, `col IN ($1, $2)`
+// and `col BETWEEN $1 AND $2`.
+func (inf *paramTypeInferer) inferFromComparison(ctx VetContext, expr *pg_query.A_Expr) {
+	switch expr.GetKind() {
+	case pg_query.A_Expr_Kind_AEXPR_OP_ANY, pg_query.A_Expr_Kind_AEXPR_OP_ALL:
+		// parameter is an array
+		return
+	}
+	lexpr := expr.GetLexpr()
+	rexpr := expr.GetRexpr()
+	if col, ok := inf.column(ctx, lexpr); ok {
+		inf.setParamType(rexpr, col.Type, false)
+		inf.checkEnumLiteral(col, rexpr)
+		for _, item := range rexpr.GetList().GetItems() {
+			inf.setParamType(item, col.Type, false)
+			inf.checkEnumLiteral(col, item)
+		}
+	}
+	if col, ok := inf.column(ctx, rexpr); ok {
+		inf.setParamType(lexpr, col.Type, false)
+		inf.checkEnumLiteral(col, lexpr)
+	}
+}
+
+func (inf *paramTypeInferer) checkEnumLiteral(col schema.Column, value *pg_query.Node) {
+	if inf.err == nil {
+		inf.err = validateEnumLiteral(col, value)
+	}
+}
+
+// typeNameString formats a TypeName node the same way column types are
+// stored in schema, e.g. pg_catalog.int4 or text[].
+func typeNameString(tn *pg_query.TypeName) string {
+	parts := []string{}
+	for _, n := range tn.GetNames() {
+		parts = append(parts, n.GetString_().GetSval())
+	}
+	typ := strings.Join(parts, ".")
+	if len(tn.GetArrayBounds()) > 0 {
+		typ += "[]"
+	}
+	return typ
+}
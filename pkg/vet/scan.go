@@ -0,0 +1,206 @@
+package vet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// ScanSite is a Scan call on rows returned by a query function
+type ScanSite struct {
+	Position  token.Position
+	DestCount int
+	Err       error
+}
+
+// validateScans checks destination count of Scan calls against columns
+// returned by the query.
+func validateScans(qs *QuerySite, cols []string) {
+	for _, scan := range qs.Scans {
+		if scan.DestCount != len(cols) {
+			scan.Err = fmt.Errorf(
+				"query returns %d columns, but Scan received %d destinations",
+				len(cols), scan.DestCount,
+			)
+		}
+	}
+}
+
+// isRowsScan reports whether a function is the Scan method of rows returned
+// by database/sql or sqlx query functions.
+func isRowsScan(name string, sig *types.Signature) bool {
+	if name != "Scan" || sig.Recv() == nil {
+		return false
+	}
+	t := sig.Recv().Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch named.Obj().Pkg().Path() {
+	case "database/sql", "github.com/jmoiron/sqlx":
+		return named.Obj().Name() == "Rows" || named.Obj().Name() == "Row"
+	}
+	return false
+}
+
+// ssaScanSites follows rows returned by a query function call to Scan calls
+func ssaScanSites(prog *ssa.Program, v ssa.Value) []*ScanSite {
+	sites := []*ScanSite{}
+	refs := v.Referrers()
+	if refs == nil {
+		return sites
+	}
+	for _, instr := range *refs {
+		switch instr := instr.(type) {
+		case *ssa.Extract:
+			// rows from (*Rows, error) results
+			if instr.Index == 0 {
+				sites = append(sites, ssaScanSites(prog, instr)...)
+			}
+		case ssa.CallInstruction:
+			common := instr.Common()
+			callee := common.StaticCallee()
+			if callee == nil || !isRowsScan(callee.Name(), callee.Signature) {
+				continue
+			}
+			if len(common.Args) != 2 || common.Args[0] != v {
+				continue
+			}
+			cnt, ok := ssaVarArgCount(common.Args[1])
+			if !ok {
+				continue
+			}
+			sites = append(sites, &ScanSite{
+				Position:  prog.Fset.Position(instr.Pos()),
+				DestCount: cnt,
+			})
+		}
+	}
+	return sites
+}
+
+// findScanCalls follows rows returned by a query function call to Scan calls
+// in the enclosing function. Rows need to be either scanned directly or
+// assigned to a local variable.
+func findScanCalls(pass *analysis.Pass, parents map[ast.Node]ast.Node, call *ast.CallExpr) []*ast.CallExpr {
+	var rowsVar types.Object
+	switch p := parents[call].(type) {
+	case *ast.SelectorExpr:
+		// e.g. db.QueryRow(query).Scan(&id)
+		if scan, ok := parents[p].(*ast.CallExpr); ok && scan.Fun == p && isScanCall(pass, scan) {
+			return []*ast.CallExpr{scan}
+		}
+		return nil
+	case *ast.AssignStmt:
+		if len(p.Rhs) == 1 && len(p.Lhs) > 0 {
+			if ident, ok := p.Lhs[0].(*ast.Ident); ok {
+				rowsVar = pass.TypesInfo.ObjectOf(ident)
+			}
+		}
+	case *ast.ValueSpec:
+		if len(p.Values) == 1 && len(p.Names) > 0 {
+			rowsVar = pass.TypesInfo.ObjectOf(p.Names[0])
+		}
+	}
+	if rowsVar == nil {
+		return nil
+	}
+
+	var body *ast.BlockStmt
+	for n := parents[call]; n != nil && body == nil; n = parents[n] {
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+	}
+	if body == nil {
+		return nil
+	}
+
+	// rows variable can be reused for other queries, Scan calls belong to
+	// the closest assignment before them
+	assigns := []token.Pos{}
+	scans := []*ast.CallExpr{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == rowsVar {
+					assigns = append(assigns, node.Pos())
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := sel.X.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == rowsVar && isScanCall(pass, node) {
+				scans = append(scans, node)
+			}
+		}
+		return true
+	})
+
+	found := []*ast.CallExpr{}
+	for _, scan := range scans {
+		if scan.Pos() < call.End() {
+			continue
+		}
+		reassigned := false
+		for _, pos := range assigns {
+			if pos > call.End() && pos < scan.Pos() {
+				reassigned = true
+				break
+			}
+		}
+		if !reassigned {
+			found = append(found, scan)
+		}
+	}
+	return found
+}
+
+func isScanCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	if call.Ellipsis.IsValid() {
+		// destinations passed in as `dest...` can't be counted
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	selection := pass.TypesInfo.Selections[sel]
+	if selection == nil {
+		return false
+	}
+	fn, ok := selection.Obj().(*types.Func)
+	return ok && isRowsScan(fn.Name(), fn.Type().(*types.Signature))
+}
+
+// parentMap maps nodes in file to their parent node
+func parentMap(file *ast.File) map[ast.Node]ast.Node {
+	parents := map[ast.Node]ast.Node{}
+	stack := []ast.Node{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if len(stack) > 0 {
+			parents[n] = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+		return true
+	})
+	return parents
+}
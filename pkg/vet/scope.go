@@ -0,0 +1,180 @@
+package vet
+
+import (
+	"fmt"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// queryScope is a query level, e.g. a statement or a subquery, along with
+// names it makes visible. References are resolved in the innermost scope
+// first, then in the enclosing ones, the same way postgres does. Names
+// defined by a subquery are not visible outside of it.
+type queryScope struct {
+	outer *queryScope
+	// ctes maps names defined by WITH clause of this level to their
+	// columns, nil means columns are unknown
+	ctes map[string][]string
+	// relations in FROM clause of this level
+	relations []scopeRelation
+}
+
+// scopeRelation is a table, CTE or subquery in FROM clause, Columns of table
+// is nil if they are unknown
+type scopeRelation struct {
+	TableUsed
+	table schema.Table
+}
+
+// hasColumn returns true if column is defined in the relation or columns of
+// the relation are unknown
+func (r scopeRelation) hasColumn(column string) bool {
+	if r.table.Columns == nil {
+		return true
+	}
+	_, ok := r.table.Columns[column]
+	return ok
+}
+
+// enterScope returns context for a nested query level
+func (ctx VetContext) enterScope() VetContext {
+	ctx.scope = &queryScope{outer: ctx.scope, ctes: map[string][]string{}}
+	return ctx
+}
+
+// withFromItems returns context in which FROM clause items parsed so far are
+// visible, e.g. for LATERAL subqueries.
+func (ctx VetContext) withFromItems(parseRe *ParseResult) (VetContext, error) {
+	ctx = ctx.enterScope()
+	return ctx, ctx.addRelations(parseRe.Tables, parseRe.subqueries)
+}
+
+// cteColumns returns columns of CTEs visible from current level
+func (ctx VetContext) cteColumns() map[string][]string {
+	ctes := map[string][]string{}
+	for s := ctx.scope; s != nil; s = s.outer {
+		for name, cols := range s.ctes {
+			if _, ok := ctes[name]; !ok {
+				ctes[name] = cols
+			}
+		}
+	}
+	return ctes
+}
+
+// derivedTable returns table for a CTE or subquery, nil cols means columns
+// are unknown
+func derivedTable(name string, cols []string) schema.Table {
+	t := schema.Table{Name: name, ReadOnly: true}
+	if cols != nil {
+		t.Columns = make(map[string]schema.Column, len(cols))
+		for _, c := range cols {
+			t.Columns[c] = schema.Column{Name: c}
+		}
+	}
+	return t
+}
+
+// addRelations resolves tables and subqueries in FROM clause of current
+// level and makes them visible to column references.
+func (ctx VetContext) addRelations(tables []TableUsed, subqueries []relation) error {
+	if ctx.Schema.Tables == nil {
+		return nil
+	}
+	for _, tu := range tables {
+		t, ok := ctx.lookupTable(tu)
+		if !ok {
+			return fmt.Errorf("invalid table name: %s", tu.QualifiedName())
+		}
+		ctx.scope.relations = append(ctx.scope.relations, scopeRelation{TableUsed: tu, table: t})
+	}
+	for _, r := range subqueries {
+		ctx.scope.relations = append(ctx.scope.relations, scopeRelation{
+			TableUsed: TableUsed{Name: r.Name},
+			table:     derivedTable(r.Name, r.Columns),
+		})
+	}
+	return nil
+}
+
+// findRelation finds relation name refers to, starting from current level.
+// Aliased tables are only visible under their alias.
+func (ctx VetContext) findRelation(name string) (scopeRelation, bool) {
+	for s := ctx.scope; s != nil; s = s.outer {
+		for _, r := range s.relations {
+			if r.Alias == name || (r.Alias == "" && r.Name == name) {
+				return r, true
+			}
+		}
+	}
+	return scopeRelation{}, false
+}
+
+// validateColumns resolves column references against relations of current
+// level and the enclosing ones.
+func (ctx VetContext) validateColumns(cols []ColumnUsed) error {
+	if ctx.Schema.Tables == nil {
+		return nil
+	}
+	for _, col := range cols {
+		if err := ctx.validateColumn(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveColumn returns definition of a column reference, false if it can't
+// be resolved or columns of its relation are unknown
+func (ctx VetContext) resolveColumn(col ColumnUsed) (schema.Column, bool) {
+	if col.Table != "" {
+		r, ok := ctx.findRelation(col.Table)
+		if !ok || (col.Schema != "" && schema.TableKey(col.Schema, col.Table) != r.table.Key()) {
+			return schema.Column{}, false
+		}
+		c, ok := r.table.Columns[col.Column]
+		return c, ok
+	}
+	for s := ctx.scope; s != nil; s = s.outer {
+		for _, r := range s.relations {
+			if c, ok := r.table.Columns[col.Column]; ok {
+				return c, true
+			}
+		}
+	}
+	return schema.Column{}, false
+}
+
+func (ctx VetContext) validateColumn(col ColumnUsed) error {
+	if col.Table != "" {
+		r, ok := ctx.findRelation(col.Table)
+		if ok && col.Schema != "" {
+			// three-part reference needs to match schema of the table
+			ok = schema.TableKey(col.Schema, col.Table) == r.table.Key()
+		}
+		if !ok {
+			return fmt.Errorf("table `%s` not available for query",
+				TableUsed{Schema: col.Schema, Name: col.Table}.QualifiedName())
+		}
+		if !r.hasColumn(col.Column) {
+			return fmt.Errorf("column `%s` is not defined in table `%s`", col.Column, col.Table)
+		}
+		return nil
+	}
+
+	// no table prefix, try all relations of each level
+	for s := ctx.scope; s != nil; s = s.outer {
+		for _, r := range s.relations {
+			if r.hasColumn(col.Column) {
+				return nil
+			}
+		}
+	}
+	if relations := ctx.scope.relations; len(relations) == 1 {
+		// to make error message more useful, if only one table is
+		// referenced in the query, it's safe to assume user only want to
+		// use columns from that table.
+		return fmt.Errorf("column `%s` is not defined in table `%s`", col.Column, relations[0].Name)
+	}
+	return fmt.Errorf("column `%s` is not defined in any of the table available for query", col.Column)
+}
@@ -0,0 +1,9 @@
+package badconfig // want `failed to load schema for .*sqlvet.toml: .*missing.sql: no such file or directory`
+
+import (
+	"database/sql"
+)
+
+func run(db *sql.DB) {
+	db.Query("SELECT id FROM users")
+}
@@ -0,0 +1,70 @@
+package dest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Foo struct {
+	ID    int    `db:"id"`
+	Value string `db:"value"`
+}
+
+type Base struct {
+	ID int `db:"id"`
+}
+
+type Bar struct {
+	Base
+	Count int `db:"count"`
+	// never loaded from database
+	Cache  string `db:"-"`
+	Note   string `db:"note,omitempty"`
+	hidden int
+}
+
+type FooWithBar struct {
+	Foo
+	Bar Bar `db:"bar"`
+}
+
+type Stats struct {
+	Total int
+	Max   sql.NullInt64
+}
+
+func get(ctx context.Context, db *sqlx.DB) {
+	var foo Foo
+	db.Get(&foo, "SELECT id, value FROM foo WHERE id = $1", 1)
+	db.Get(&foo, "SELECT * FROM foo WHERE id = $1", 1)
+	db.Get(&foo, "SELECT id, value, 1 AS extra FROM foo")        // want `missing destination name extra in dest.Foo`
+	db.Get(&foo, "SELECT id FROM foo")                           // want "field `value` of dest.Foo is not selected by query"
+	db.GetContext(ctx, &foo, "SELECT id, value || 'x' FROM foo") // want `missing destination name \?column\? in dest.Foo`
+
+	var bar Bar
+	db.Get(&bar, "SELECT id, count FROM bar")
+	db.Get(&bar, "SELECT count FROM bar") // want "field `id` of dest.Bar is not selected by query"
+
+	var fooBar FooWithBar
+	db.Get(&fooBar, `SELECT foo.id, foo.value, bar.id AS "bar.id", bar.count AS "bar.count" FROM foo JOIN bar ON foo.id = bar.id`)
+	db.Get(&fooBar, `SELECT foo.id, foo.value, bar.id AS "bar.id" FROM foo JOIN bar ON foo.id = bar.id`) // want "field `bar.count` of dest.FooWithBar is not selected by query"
+
+	var stats Stats
+	db.Get(&stats, "SELECT count(*) AS total, max(id) FROM foo")
+
+	var id int
+	db.Get(&id, "SELECT id FROM foo")
+	db.Get(&id, "SELECT id, value FROM foo") // want `scannable dest type int with >1 columns \(2\) in result`
+}
+
+func selectRows(ctx context.Context, db *sqlx.DB) {
+	var foos []Foo
+	db.Select(&foos, "SELECT id, value FROM foo")
+	db.Select(&foos, "SELECT id, value, id AS foo_id FROM foo") // want `missing destination name foo_id in dest.Foo`
+
+	var bars []*Bar
+	db.SelectContext(ctx, &bars, "SELECT * FROM bar")
+	db.SelectContext(ctx, &bars, "SELECT id FROM bar") // want "field `count` of dest.Bar is not selected by query"
+}
@@ -0,0 +1,9 @@
+CREATE TABLE foo (
+    id int,
+    value text
+);
+
+CREATE TABLE bar (
+    id int,
+    count int
+);
@@ -0,0 +1,56 @@
+// Package sqlx is a minimal stand-in for github.com/jmoiron/sqlx used by
+// analyzer tests.
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DB struct {
+	*sql.DB
+}
+
+func (db *DB) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+func (db *DB) Get(dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) Select(dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (db *DB) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+func Named(query string, arg interface{}) (string, []interface{}, error) {
+	return query, nil, nil
+}
+
+func (db *DB) Rebind(query string) string {
+	return query
+}
+
+func In(query string, args ...interface{}) (string, []interface{}, error) {
+	return query, args, nil
+}
+
+func Rebind(bindType int, query string) string {
+	return query
+}
+
+func BindNamed(bindType int, query string, arg interface{}) (string, []interface{}, error) {
+	return query, nil, nil
+}
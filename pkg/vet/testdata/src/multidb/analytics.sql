@@ -0,0 +1 @@
+CREATE TABLE events (id int);
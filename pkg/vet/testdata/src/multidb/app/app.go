@@ -0,0 +1,23 @@
+package app
+
+import (
+	"database/sql"
+
+	"multidb/billing"
+	"multidb/reportdb"
+	"multidb/store"
+)
+
+func run(db *sql.DB, analytics *store.AnalyticsDB) {
+	db.Query("SELECT id FROM users")
+	db.Query("SELECT id FROM invoices") // want `invalid table name: invoices`
+
+	analytics.Query("SELECT id FROM events")
+	analytics.Query("SELECT id FROM users") // want `invalid table name: users`
+
+	reportdb.Run(db, "SELECT id FROM reports")
+	reportdb.Run(db, "SELECT id FROM users") // want `invalid table name: users`
+
+	billing.Exec(db, "SELECT id FROM invoices")
+	billing.Exec(db, "SELECT id FROM users") // want `invalid table name: users`
+}
@@ -0,0 +1 @@
+CREATE TABLE invoices (id int);
@@ -0,0 +1,16 @@
+package billing
+
+import (
+	"database/sql"
+)
+
+func run(db *sql.DB) {
+	db.Query("SELECT id FROM invoices")
+	db.Query("SELECT id FROM users") // want `invalid table name: users`
+}
+
+// Exec runs query against billing database
+func Exec(db *sql.DB, query string) error { // want Exec:`queryFunc\(1, pkg=multidb/billing\)`
+	_, err := db.Exec(query)
+	return err
+}
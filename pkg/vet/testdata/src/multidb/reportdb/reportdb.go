@@ -0,0 +1,10 @@
+package reportdb
+
+import (
+	"database/sql"
+)
+
+func Run(db *sql.DB, query string) error {
+	_, err := db.Exec(query)
+	return err
+}
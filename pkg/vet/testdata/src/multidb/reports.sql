@@ -0,0 +1 @@
+CREATE TABLE reports (id int);
@@ -0,0 +1,9 @@
+package store
+
+import (
+	"database/sql"
+)
+
+type AnalyticsDB struct {
+	*sql.DB
+}
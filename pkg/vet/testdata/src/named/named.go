@@ -0,0 +1,48 @@
+package named
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Base struct {
+	ID int `db:"id"`
+}
+
+type User struct {
+	Name string `db:"name"`
+}
+
+type Foo struct {
+	Base
+	Value string `db:"value"`
+	Owner User   `db:"owner"`
+	Note  string
+}
+
+func named(ctx context.Context, db *sqlx.DB, foo Foo, foos []*Foo, arg map[string]interface{}) {
+	db.NamedExec("INSERT INTO foo (id, value) VALUES (:id, :value)", foo)
+	db.NamedExec("UPDATE foo SET value = :value WHERE id = :id", &foo)
+	db.NamedExec("INSERT INTO foo (id, value) VALUES (:id, :val)", foo)         // want `could not find name val in named.Foo`
+	db.NamedExec("INSERT INTO foo (id, value) VALUES (:id, :value)", foos)      // batch insert
+	db.NamedExec("INSERT INTO foo (id, value) VALUES (:id, :owner_name)", foos) // want `could not find name owner_name in named.Foo`
+	db.NamedExecContext(ctx, "UPDATE foo SET value = :owner.name WHERE id = :id", foo)
+	db.NamedExecContext(ctx, "UPDATE foo SET value = :owner.id WHERE id = :id", foo) // want `could not find name owner.id in named.Foo`
+
+	// untagged fields are mapped to lower case names
+	db.NamedExec("UPDATE foo SET value = :note WHERE id = :id", foo)
+
+	db.NamedExec("UPDATE foo SET value = :value WHERE id = :id", map[string]interface{}{
+		"id":    1,
+		"value": "bar",
+	})
+	db.NamedExec("UPDATE foo SET value = :value WHERE id = :id", map[string]interface{}{ // want `could not find name value in map\[string\]interface\{\} literal`
+		"id": 1,
+	})
+	// keys are not known
+	db.NamedExec("UPDATE foo SET value = :value WHERE id = :id", arg)
+
+	sqlx.Named("SELECT id FROM foo WHERE value = :value", foo)
+	sqlx.Named("SELECT id FROM foo WHERE value = :name", foo) // want `could not find name name in named.Foo`
+}
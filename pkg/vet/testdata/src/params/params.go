@@ -0,0 +1,34 @@
+package params
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func positional(db *sql.DB, id int, value string, args []interface{}) {
+	db.Query("SELECT 1")
+	db.Query("SELECT id FROM foo WHERE id = $1 AND value = $2", id, value)
+	db.Query("SELECT id FROM foo WHERE id = $1 OR value = $1", id)
+	db.Query("SELECT id FROM foo WHERE id = $1::int", id)
+
+	db.Query("SELECT id FROM foo WHERE id = $1 AND value = $2", id) // want `query expects 2 parameters, but received 1 from function call`
+	db.Exec("UPDATE foo SET value = $1", value, id)                 // want `query expects 1 parameters, but received 2 from function call`
+	db.Query("SELECT id FROM foo WHERE id = $1")                    // want `query expects 1 parameters, but received 0 from function call`
+
+	// argument count is unknown for spreads
+	db.Query("SELECT id FROM foo WHERE id = $1 AND value = $2", args...)
+
+	db.Query("SELECT id FROM foo WHERE id = $1 AND value = $3", args...) // want `query parameter \$2 is not used, parameters must be numbered without gaps`
+}
+
+func named(db *sqlx.DB, arg interface{}) {
+	db.NamedExec("INSERT INTO foo (id, value) VALUES (:id, :value)", arg)
+	db.NamedExec("UPDATE foo SET value = :value WHERE id = :id OR :id IS NULL", arg)
+	db.NamedExec("UPDATE foo SET value = ':value' WHERE id = :id", arg) // want `query expects 1 parameters, but 2 are bound from named parameters`
+
+	// named parameters are not bound by positional query functions
+	var id int
+	db.Get(&id, "SELECT id FROM foo WHERE value = :value", arg) // want `syntax error at or near ":"`
+	db.Select(&id, "SELECT id FROM foo WHERE value = $1", "bar")
+}
@@ -0,0 +1,28 @@
+package paramtype
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ID int64
+
+func query(db *sql.DB, id int, idStr string, value string, ts time.Time, tags []string) {
+	db.Query("SELECT value FROM foo WHERE id = $1", id)
+	db.Query("SELECT value FROM foo WHERE id = $1", ID(1))
+	db.Query("SELECT value FROM foo WHERE id = $1", sql.NullInt64{})
+	db.Query("SELECT value FROM foo WHERE id = $1", idStr) // want `query parameter \$1 expects int4, but received string argument`
+	db.Query("SELECT value FROM foo WHERE id = $1::text", idStr)
+	db.Query("SELECT value FROM foo WHERE id IN ($1, $2)", id, "2") // want `query parameter \$2 expects int4, but received string argument`
+	db.Query("SELECT value FROM foo WHERE tags = $1", tags)
+	db.Query("SELECT value FROM foo WHERE created_at > $1 LIMIT $2", ts, id)
+	db.Query("SELECT value FROM foo WHERE created_at > $1 LIMIT $2", ts, value) // want `query parameter \$2 expects int8, but received string argument`
+	db.Query("SELECT value FROM foo WHERE $1 = id", true)                       // want `query parameter \$1 expects int4, but received bool argument`
+}
+
+func exec(db *sql.DB, id int, value string) {
+	db.Exec("INSERT INTO foo (id, value, active) VALUES ($1, $2, $3)", id, value, true)
+	db.Exec("INSERT INTO foo (id, value) VALUES ($1, $2)", value, value) // want `query parameter \$1 expects int4, but received string argument`
+	db.Exec("UPDATE foo SET active = $1 WHERE id = $2", 1, id)           // want `query parameter \$1 expects bool, but received int argument`
+	db.Exec("UPDATE foo SET created_at = $1 WHERE id = $2", nil, id)
+}
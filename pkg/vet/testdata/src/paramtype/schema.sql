@@ -0,0 +1,7 @@
+CREATE TABLE foo (
+    id int,
+    value text,
+    active boolean,
+    created_at timestamptz,
+    tags text[]
+);
@@ -0,0 +1,17 @@
+package rebind
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+func rebind(db *sqlx.DB, ids []int) {
+	// bind type helpers don't run the query, `?` placeholders are fine
+	query, args, _ := sqlx.In("SELECT id FROM foo WHERE id IN (?)", ids)
+	db.Rebind("SELECT id FROM foo WHERE id = ?")
+	sqlx.Rebind(1, "SELECT id FROM foo WHERE id = ?")
+	sqlx.BindNamed(1, "SELECT id FROM foo WHERE id = :id", map[string]interface{}{"id": 1})
+
+	var foos []int
+	db.Select(&foos, db.Rebind(query), args...)
+	db.Select(&foos, "SELECT id FROM foo WHERE id = ?") // want `syntax error at end of input`
+}
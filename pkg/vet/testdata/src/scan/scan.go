@@ -0,0 +1,35 @@
+package scan
+
+import (
+	"database/sql"
+)
+
+func scan(db *sql.DB) {
+	var id, count int
+	var value string
+
+	db.QueryRow("SELECT id, value FROM foo WHERE id = $1", 1).Scan(&id, &value)
+	db.QueryRow("SELECT id FROM foo").Scan(&id, &value) // want `query returns 1 columns, but Scan received 2 destinations`
+
+	// star is expanded from schema
+	db.QueryRow("SELECT * FROM foo").Scan(&id, &value)
+	db.QueryRow("SELECT * FROM foo").Scan(&id)                                                // want `query returns 2 columns, but Scan received 1 destinations`
+	db.QueryRow("SELECT f.*, b.count FROM foo f JOIN bar b ON f.id = b.id").Scan(&id, &value) // want `query returns 3 columns, but Scan received 2 destinations`
+	db.QueryRow("SELECT * FROM foo JOIN bar USING (id)").Scan(&id, &value, &count)
+
+	db.QueryRow("INSERT INTO foo (id, value) VALUES ($1, $2) RETURNING id", 1, "a").Scan(&id, &value) // want `query returns 1 columns, but Scan received 2 destinations`
+
+	rows, _ := db.Query("SELECT id, value FROM foo")
+	for rows.Next() {
+		rows.Scan(&id) // want `query returns 2 columns, but Scan received 1 destinations`
+	}
+	rows, _ = db.Query("SELECT id FROM foo")
+	for rows.Next() {
+		rows.Scan(&id)
+	}
+
+	// destinations passed in as slice are not counted
+	dest := []interface{}{&id}
+	row := db.QueryRow("SELECT id, value FROM foo")
+	row.Scan(dest...)
+}
@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+
+	"wrapper/repo"
+)
+
+func run(ctx context.Context, r *repo.Repo, db *sql.DB) {
+	r.Exec(ctx, "SELECT 1")
+	r.Exec(ctx, "SELEC 1")             // want `syntax error at or near "SELEC"`
+	r.Exec(ctx, "SELEC 2")             // sqlvet: ignore
+	repo.QueryOne(db, "SELECT 1 FROM") // want `syntax error at end of input`
+	repo.QueryLimit(db, "SELECT 1 FROM")
+}
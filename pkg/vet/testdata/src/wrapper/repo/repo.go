@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Repo struct {
+	db *sql.DB
+}
+
+func (r *Repo) exec(ctx context.Context, query string, args ...interface{}) error { // want exec:`queryFunc\(1, args, pkg=wrapper/repo\)`
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *Repo) Exec(ctx context.Context, query string, args ...interface{}) error { // want Exec:`queryFunc\(1, args, pkg=wrapper/repo\)`
+	return r.exec(ctx, query, args...)
+}
+
+func QueryOne(db *sql.DB, query string) *sql.Row { // want QueryOne:`queryFunc\(1, pkg=wrapper/repo\)`
+	return db.QueryRow(query)
+}
+
+// query string is modified before being passed down, call sites can't be
+// validated with the constant they pass in
+func QueryLimit(db *sql.DB, query string) *sql.Row {
+	query = query + " LIMIT 1"
+	return db.QueryRow(query)
+}
+
+func (r *Repo) Update(ctx context.Context) error {
+	return r.exec(ctx, "UPDATE foo SET") // want `syntax error at end of input`
+}
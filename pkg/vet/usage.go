@@ -0,0 +1,172 @@
+package vet
+
+import (
+	"sort"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// Usage holds schema tables and columns referenced by queries, tables are
+// keyed the same way as schema.Db.Tables
+type Usage struct {
+	Tables  map[string]bool
+	Columns map[string]map[string]bool
+}
+
+func NewUsage() *Usage {
+	return &Usage{
+		Tables:  map[string]bool{},
+		Columns: map[string]map[string]bool{},
+	}
+}
+
+func (u *Usage) addTable(key string) {
+	u.Tables[key] = true
+}
+
+func (u *Usage) addColumn(key string, column string) {
+	u.addTable(key)
+	if u.Columns[key] == nil {
+		u.Columns[key] = map[string]bool{}
+	}
+	u.Columns[key][column] = true
+}
+
+// Merge adds tables and columns used by other
+func (u *Usage) Merge(other *Usage) {
+	if other == nil {
+		return
+	}
+	for key := range other.Tables {
+		u.addTable(key)
+	}
+	for key, cols := range other.Columns {
+		for col := range cols {
+			u.addColumn(key, col)
+		}
+	}
+}
+
+// Unused returns sorted keys of tables never referenced and, for referenced
+// tables, sorted names of columns never referenced.
+func (u *Usage) Unused(tables map[string]schema.Table) ([]string, map[string][]string) {
+	unusedTables := []string{}
+	unusedCols := map[string][]string{}
+	for key, table := range tables {
+		if !u.Tables[key] {
+			unusedTables = append(unusedTables, key)
+			continue
+		}
+		for name := range table.Columns {
+			if !u.Columns[key][name] {
+				unusedCols[key] = append(unusedCols[key], name)
+			}
+		}
+		sort.Strings(unusedCols[key])
+	}
+	sort.Strings(unusedTables)
+	return unusedTables, unusedCols
+}
+
+// queryUsage collects schema tables and columns referenced by a validated
+// statement. Columns are resolved against all tables the statement
+// references rather than their own scope, so a column counts as used if any
+// of them could provide it. Over-counting keeps columns still in use from
+// being reported.
+func queryUsage(ctx VetContext, stmt *pg_query.Node) *Usage {
+	usage := NewUsage()
+	if ctx.Schema.Tables == nil {
+		return usage
+	}
+
+	ctes := map[string]bool{}
+	walkNodes(stmt, func(n *pg_query.Node) bool {
+		if cte := n.GetCommonTableExpr(); cte != nil {
+			ctes[cte.GetCtename()] = true
+		}
+		return true
+	})
+
+	// tables by name and alias, the same name can refer to several tables
+	// in different subqueries
+	refs := map[string][]schema.Table{}
+	tables := []schema.Table{}
+	walkNodes(stmt, func(n *pg_query.Node) bool {
+		rv := n.GetRangeVar()
+		if rv == nil {
+			return true
+		}
+		tu := rangeVarToTableUsed(rv)
+		if tu.Schema == "" && ctes[tu.Name] {
+			return true
+		}
+		t, ok := schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, tu.Schema, tu.Name)
+		if !ok {
+			return true
+		}
+		usage.addTable(t.Key())
+		tables = append(tables, t)
+		refs[tu.Name] = append(refs[tu.Name], t)
+		if tu.Alias != "" {
+			refs[tu.Alias] = append(refs[tu.Alias], t)
+		}
+		return true
+	})
+
+	addColumn := func(candidates []schema.Table, column string) {
+		for _, t := range candidates {
+			if column == "*" {
+				for name := range t.Columns {
+					usage.addColumn(t.Key(), name)
+				}
+			} else if _, ok := t.Columns[column]; ok {
+				usage.addColumn(t.Key(), column)
+			}
+		}
+	}
+
+	// target columns of INSERT and UPDATE are plain names instead of column
+	// references
+	addTargets := func(rv *pg_query.RangeVar, targets []*pg_query.Node, insert bool) {
+		tu := rangeVarToTableUsed(rv)
+		t, ok := schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, tu.Schema, tu.Name)
+		if !ok {
+			return
+		}
+		if insert && len(targets) == 0 {
+			// values are matched with all columns in order
+			addColumn([]schema.Table{t}, "*")
+		}
+		for _, target := range targets {
+			addColumn([]schema.Table{t}, target.GetResTarget().GetName())
+		}
+	}
+
+	walkNodes(stmt, func(n *pg_query.Node) bool {
+		switch node := n.GetNode().(type) {
+		case *pg_query.Node_ColumnRef:
+			fields := node.ColumnRef.GetFields()
+			if len(fields) == 0 {
+				return true
+			}
+			column := "*"
+			if cu := columnRefToColumnUsed(node.ColumnRef); cu != nil {
+				column = cu.Column
+			}
+			if len(fields) == 1 {
+				addColumn(tables, column)
+				return true
+			}
+			qualifier := fields[len(fields)-2].GetString_().GetSval()
+			addColumn(refs[qualifier], column)
+		case *pg_query.Node_InsertStmt:
+			addTargets(node.InsertStmt.GetRelation(), node.InsertStmt.GetCols(), true)
+		case *pg_query.Node_UpdateStmt:
+			addTargets(node.UpdateStmt.GetRelation(), node.UpdateStmt.GetTargetList(), false)
+		}
+		return true
+	})
+	return usage
+}
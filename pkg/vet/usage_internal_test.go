@@ -0,0 +1,46 @@
+package vet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+func TestQueryUsage(t *testing.T) {
+	tables := map[string]schema.Table{
+		"users": {
+			Name: "users",
+			Columns: map[string]schema.Column{
+				"id": {Name: "id"}, "name": {Name: "name"}, "legacy": {Name: "legacy"},
+			},
+		},
+		"posts": {
+			Name: "posts",
+			Columns: map[string]schema.Column{
+				"id": {Name: "id"}, "author_id": {Name: "author_id"}, "body": {Name: "body"},
+			},
+		},
+		"audit_log": {
+			Name:    "audit_log",
+			Columns: map[string]schema.Column{"id": {Name: "id"}},
+		},
+	}
+
+	usage := NewUsage()
+	for _, query := range []string{
+		"SELECT u.name FROM users u JOIN posts p ON p.author_id = u.id",
+		"WITH recent AS (SELECT * FROM posts) SELECT id FROM recent",
+		"UPDATE users SET name = $1 WHERE id = $2",
+	} {
+		ctx := newSchemaContext(Schema{Tables: tables})
+		_, stmt, err := validateSqlQueryStmt(ctx, query)
+		require.NoError(t, err, query)
+		usage.Merge(queryUsage(ctx, stmt))
+	}
+
+	unusedTables, unusedCols := usage.Unused(tables)
+	require.Equal(t, []string{"audit_log"}, unusedTables)
+	require.Equal(t, map[string][]string{"users": {"legacy"}}, unusedCols)
+}
@@ -3,63 +3,86 @@ package vet
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 
-	"github.com/houqp/sqlvet/pkg/schema"
+	pg_query "github.com/pganalyze/pg_query_go/v6"
 	pg_wasm "github.com/wasilibs/go-pgquery"
+
+	"github.com/houqp/sqlvet/pkg/parseutil"
+	"github.com/houqp/sqlvet/pkg/schema"
 )
 
 type Schema struct {
 	Tables map[string]schema.Table
+	// Functions defined in schema, see schema.Db
+	Functions map[string][]schema.Function
+	// Extensions created in schema, see schema.Db
+	Extensions []string
+	// SearchPath resolves unqualified table names, schema.DefaultSearchPath
+	// is used when empty
+	SearchPath []string
 }
 
 func NewContext(tables map[string]schema.Table) VetContext {
-	return VetContext{
-		Schema:      Schema{Tables: tables},
-		InnerSchema: Schema{Tables: map[string]schema.Table{}},
-	}
+	return VetContext{Schema: Schema{Tables: tables}}
 }
 
-type VetContext struct {
-	Schema      Schema
-	InnerSchema Schema
-	UsedTables  []TableUsed
+// newSchemaContext creates context for validating a query against s
+func newSchemaContext(s Schema) VetContext {
+	ctx := NewContext(s.Tables)
+	ctx.Schema.Functions = s.Functions
+	ctx.Schema.Extensions = s.Extensions
+	ctx.Schema.SearchPath = s.SearchPath
+	return ctx
 }
 
-type TableUsed struct {
-	Name  string
-	Alias string
+type VetContext struct {
+	Schema Schema
+	// scope of the query level being validated
+	scope *queryScope
 }
 
-type ColumnUsed struct {
-	Column   string
-	Table    string
-	Location int32
+// lookupTable resolves a table reference, unqualified names can refer to
+// CTEs of current and enclosing query levels as well.
+func (ctx VetContext) lookupTable(tu TableUsed) (schema.Table, bool) {
+	if tu.Schema == "" {
+		for s := ctx.scope; s != nil; s = s.outer {
+			if cols, ok := s.ctes[tu.Name]; ok {
+				return derivedTable(tu.Name, cols), true
+			}
+		}
+	}
+	return schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, tu.Schema, tu.Name)
 }
 
-type QueryParam struct {
-	Number int32
-	// TODO: also store related column type info for analysis
+type TableUsed struct {
+	// Schema is only set for schema qualified references, e.g. auth.users
+	Schema string
+	Name   string
+	Alias  string
 }
 
-type PostponedNodes struct {
-	RangeSubselectNodes []any
+// QualifiedName returns table name as referenced in query
+func (t TableUsed) QualifiedName() string {
+	if t.Schema != "" {
+		return t.Schema + "." + t.Name
+	}
+	return t.Name
 }
 
-func (p *PostponedNodes) Parse(ctx VetContext, parseRe *ParseResult) (err error) {
-	for _, r := range p.RangeSubselectNodes {
-		if err = parseRangeSubselect(ctx, r, parseRe); err != nil {
-			return err
-		}
-	}
-	return nil
+type ColumnUsed struct {
+	Column string
+	Table  string
+	// Schema is only set for three-part references, e.g. auth.users.id
+	Schema   string
+	Location int32
 }
 
-func (p *PostponedNodes) Append(other *PostponedNodes) {
-	if other == nil {
-		return
-	}
-	p.RangeSubselectNodes = append(p.RangeSubselectNodes, other.RangeSubselectNodes...)
+type QueryParam struct {
+	Number int32
+	// SQL type inferred from where the parameter is used, empty if unknown
+	Type string
 }
 
 type ParseResult struct {
@@ -67,7 +90,8 @@ type ParseResult struct {
 	Tables  []TableUsed
 	Params  []QueryParam
 
-	PostponedNodes *PostponedNodes
+	// subqueries in FROM clause, by alias
+	subqueries []relation
 }
 
 // insert query param based on parameter number and avoid deduplications
@@ -105,167 +129,864 @@ func DebugQuery(q string) {
 	fmt.Println("parsed query: " + pretty.String())
 }
 
-// rangeVarToTableUsed is not used in JSON mode
-
-/* =========================================================================================================
-	this is where the rewrite to nil checks rather than type refleciton starts
- ========================================================================================================= */
+func rangeVarToTableUsed(r *pg_query.RangeVar) TableUsed {
+	return TableUsed{
+		Schema: r.GetSchemaname(),
+		Name:   r.GetRelname(),
+		Alias:  r.GetAlias().GetAliasname(),
+	}
+}
 
 // return nil if no specific column is being referenced
-// columnRefToColumnUsed is unused in JSON mode
-func columnRefToColumnUsed(_ any) *ColumnUsed { return nil }
-
-func getUsedTablesFromJoinArg(_ any) []TableUsed { return []TableUsed{} }
-
-// extract used tables from FROM clause and JOIN clauses
-// TODO ? maybe this should be moved to parseExpression() and be collected in ParseResult
-func getUsedTablesFromSelectStmt(_ interface{}) []TableUsed { return []TableUsed{} }
-
-func parseFromClause(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+func columnRefToColumnUsed(colRef *pg_query.ColumnRef) *ColumnUsed {
+	fields := colRef.GetFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	// column, table.column or schema.table.column, last field is A_Star
+	// for `*` and `table.*`
+	colField := fields[len(fields)-1].GetString_()
+	if colField == nil {
+		return nil
+	}
+	cu := ColumnUsed{Column: colField.GetSval(), Location: colRef.GetLocation()}
+	if len(fields) > 1 {
+		cu.Table = fields[len(fields)-2].GetString_().GetSval()
+	}
+	if len(fields) > 2 {
+		cu.Schema = fields[len(fields)-3].GetString_().GetSval()
+	}
+	return &cu
+}
 
-func getUsedColumnsFromReturningList(_ interface{}) []ColumnUsed { return []ColumnUsed{} }
+func parseFromClause(ctx VetContext, clause *pg_query.Node, parseRe *ParseResult) error {
+	switch n := clause.GetNode().(type) {
+	case nil:
+		return nil
+	case *pg_query.Node_RangeVar:
+		parseRe.Tables = append(parseRe.Tables, rangeVarToTableUsed(n.RangeVar))
+	case *pg_query.Node_JoinExpr:
+		return parseJoinExpr(ctx, n.JoinExpr, parseRe)
+	case *pg_query.Node_RangeSubselect:
+		return parseRangeSubselect(ctx, clause, parseRe)
+	case *pg_query.Node_RangeFunction:
+		return parseRangeFunction(ctx, n.RangeFunction, parseRe)
+	default:
+		// e.g. TABLESAMPLE and XMLTABLE
+		return parseExpressionChildren(ctx, clause, parseRe)
+	}
+	return nil
+}
 
-func validateTable(ctx VetContext, tname string, notReadOnly bool) error {
+func validateTable(ctx VetContext, tu TableUsed, notReadOnly bool) error {
 	if ctx.Schema.Tables == nil {
 		return nil
 	}
-	t, ok := ctx.Schema.Tables[tname]
+	t, ok := schema.LookupTable(ctx.Schema.Tables, ctx.Schema.SearchPath, tu.Schema, tu.Name)
 	if !ok {
-		return fmt.Errorf("invalid table name: %s", tname)
+		return fmt.Errorf("invalid table name: %s", tu.QualifiedName())
 	}
 	if notReadOnly && t.ReadOnly {
-		return fmt.Errorf("read-only table: %s", tname)
+		return fmt.Errorf("read-only table: %s", tu.QualifiedName())
 	}
 	return nil
 }
 
-func validateTableColumns(ctx VetContext, tables []TableUsed, cols []ColumnUsed) error {
-	if ctx.Schema.Tables == nil || ctx.InnerSchema.Tables == nil {
+// validateInsertColumns checks NOT NULL columns without default are not left
+// out of INSERT, and GENERATED ALWAYS columns are only written with DEFAULT.
+// Identity columns can be written with OVERRIDING SYSTEM VALUE.
+func validateInsertColumns(table schema.Table, cols []ColumnUsed, values []*pg_query.Node, overridingSystemValue bool) error {
+	if len(cols) == 0 {
+		// values are matched with all table columns in order
 		return nil
 	}
+	targets := map[string]bool{}
+	for _, col := range cols {
+		targets[col.Column] = true
+	}
+	if err := validateDefaultedColumns(table, targets); err != nil {
+		return err
+	}
 
-	var ok bool
-	usedTables := map[string]schema.Table{}
-	for _, tu := range tables {
-		usedTables[tu.Name], ok = ctx.InnerSchema.Tables[tu.Name]
-		if !ok {
-			usedTables[tu.Name], ok = ctx.Schema.Tables[tu.Name]
-			if !ok {
-				return fmt.Errorf("invalid table name: %s", tu.Name)
+	for i, target := range cols {
+		col := table.Columns[target.Column]
+		if !col.GeneratedAlways || (col.Identity && overridingSystemValue) {
+			continue
+		}
+		for j := i; j < len(values); j += len(cols) {
+			if values[j].GetSetToDefault() == nil {
+				return fmt.Errorf("cannot insert into GENERATED ALWAYS column `%s`", target.Column)
 			}
 		}
-		if tu.Alias != "" {
-			usedTables[tu.Alias] = usedTables[tu.Name]
+	}
+	return nil
+}
+
+// validateDefaultedColumns reports NOT NULL columns without a default that
+// are not among targets of an INSERT, i.e. set to their default
+func validateDefaultedColumns(table schema.Table, targets map[string]bool) error {
+	for _, name := range table.ColumnNames() {
+		col := table.Columns[name]
+		if col.NotNull && !col.HasDefault && !targets[name] {
+			return fmt.Errorf("missing value for NOT NULL column `%s` in table `%s`", name, table.Key())
 		}
 	}
+	return nil
+}
 
-	for _, col := range cols {
-		if col.Table != "" {
-			table, ok := usedTables[col.Table]
-			if !ok {
-				return fmt.Errorf("table `%s` not available for query", col.Table)
-			}
-			_, ok = table.Columns[col.Column]
-			if !ok {
-				return fmt.Errorf("column `%s` is not defined in table `%s`", col.Column, col.Table)
-			}
-		} else {
-			// no table prefix, try all tables
-			found := false
-			for _, table := range usedTables {
-				_, ok = table.Columns[col.Column]
-				if ok {
-					found = true
-					break
-				}
-			}
-			if !found {
-				if len(usedTables) == 1 {
-					// to make error message more useful, if only one table is
-					// referenced in the query, it's safe to assume user only
-					// want to use columns from that table.
-					return fmt.Errorf(
-						"column `%s` is not defined in table `%s`",
-						col.Column, tables[0].Name)
-				} else {
-					return fmt.Errorf(
-						"column `%s` is not defined in any of the table available for query",
-						col.Column)
+// validateInsertValues type checks literal values against target columns,
+// values from multiple rows are passed in as one flat list. Caller makes sure
+// value count is a multiple of column count.
+func validateInsertValues(table schema.Table, cols []ColumnUsed, values []*pg_query.Node) error {
+	for i, value := range values {
+		column := table.Columns[cols[i%len(cols)].Column]
+		if err := validateLiteralType(column, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTargetValues type checks literal values in SET targets of UPDATE
+// and ON CONFLICT DO UPDATE.
+func validateTargetValues(table schema.Table, targetList []*pg_query.Node) error {
+	for _, n := range targetList {
+		target := n.GetResTarget()
+		if target == nil {
+			continue
+		}
+		column := table.Columns[target.GetName()]
+		if column.GeneratedAlways && target.GetVal().GetSetToDefault() == nil {
+			return fmt.Errorf("column `%s` can only be updated to DEFAULT", column.Name)
+		}
+		if err := validateLiteralType(column, target.GetVal()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseWindowDef walks PARTITION BY, ORDER BY and frame offsets of a window
+// definition
+func parseWindowDef(ctx VetContext, winDef *pg_query.WindowDef, parseRe *ParseResult) error {
+	if err := parseExpressions(ctx, parseRe, winDef.GetPartitionClause()...); err != nil {
+		return err
+	}
+	if err := parseExpressions(ctx, parseRe, winDef.GetOrderClause()...); err != nil {
+		return err
+	}
+	return parseExpressions(ctx, parseRe, winDef.GetStartOffset(), winDef.GetEndOffset())
+}
+
+// recursive function to parse expressions including nested expressions,
+// collects columns, params and tables referenced by clause. Subqueries are
+// validated on their own. Node kinds without a case of their own have all
+// child nodes walked, so nothing is skipped.
+func parseExpression(ctx VetContext, clause *pg_query.Node, parseRe *ParseResult) error {
+	switch n := clause.GetNode().(type) {
+	case nil:
+		return nil
+	case *pg_query.Node_AExpr:
+		// IN lists, BETWEEN bounds and NULLIF arguments are kept in lexpr
+		// and rexpr as well
+		return parseExpressions(ctx, parseRe, n.AExpr.GetLexpr(), n.AExpr.GetRexpr())
+	case *pg_query.Node_BoolExpr:
+		return parseExpressions(ctx, parseRe, n.BoolExpr.GetArgs()...)
+	case *pg_query.Node_CoalesceExpr:
+		return parseExpressions(ctx, parseRe, n.CoalesceExpr.GetArgs()...)
+	case *pg_query.Node_MinMaxExpr:
+		return parseExpressions(ctx, parseRe, n.MinMaxExpr.GetArgs()...)
+	case *pg_query.Node_RowExpr:
+		return parseExpressions(ctx, parseRe, n.RowExpr.GetArgs()...)
+	case *pg_query.Node_GroupingFunc:
+		return parseExpressions(ctx, parseRe, n.GroupingFunc.GetArgs()...)
+	case *pg_query.Node_NullIfExpr:
+		return parseExpressions(ctx, parseRe, n.NullIfExpr.GetArgs()...)
+	case *pg_query.Node_NullTest:
+		return parseExpression(ctx, n.NullTest.GetArg(), parseRe)
+	case *pg_query.Node_BooleanTest:
+		return parseExpression(ctx, n.BooleanTest.GetArg(), parseRe)
+	case *pg_query.Node_TypeCast:
+		return parseExpression(ctx, n.TypeCast.GetArg(), parseRe)
+	case *pg_query.Node_CollateClause:
+		return parseExpression(ctx, n.CollateClause.GetArg(), parseRe)
+	case *pg_query.Node_NamedArgExpr:
+		return parseExpression(ctx, n.NamedArgExpr.GetArg(), parseRe)
+	case *pg_query.Node_ColumnRef:
+		if cu := columnRefToColumnUsed(n.ColumnRef); cu != nil {
+			parseRe.Columns = append(parseRe.Columns, *cu)
+		}
+	case *pg_query.Node_ParamRef:
+		AddQueryParam(&parseRe.Params, QueryParam{Number: n.ParamRef.GetNumber()})
+	case *pg_query.Node_AConst, *pg_query.Node_SqlvalueFunction, *pg_query.Node_SetToDefault,
+		*pg_query.Node_AStar, *pg_query.Node_String_, *pg_query.Node_Integer,
+		*pg_query.Node_Float, *pg_query.Node_Boolean:
+		// literals and values without column references
+	case *pg_query.Node_FuncCall:
+		fc := n.FuncCall
+		if err := validateFuncCall(ctx, fc); err != nil {
+			return err
+		}
+		if err := parseExpressions(ctx, parseRe, fc.GetArgs()...); err != nil {
+			return err
+		}
+		// aggregate ORDER BY and FILTER (WHERE ...)
+		if err := parseExpressions(ctx, parseRe, fc.GetAggOrder()...); err != nil {
+			return err
+		}
+		if err := parseExpression(ctx, fc.GetAggFilter(), parseRe); err != nil {
+			return err
+		}
+		if fc.GetOver() != nil {
+			return parseWindowDef(ctx, fc.GetOver(), parseRe)
+		}
+	case *pg_query.Node_CaseExpr:
+		if err := parseExpression(ctx, n.CaseExpr.GetArg(), parseRe); err != nil {
+			return err
+		}
+		if err := parseExpressions(ctx, parseRe, n.CaseExpr.GetArgs()...); err != nil {
+			return err
+		}
+		return parseExpression(ctx, n.CaseExpr.GetDefresult(), parseRe)
+	case *pg_query.Node_CaseWhen:
+		return parseExpressions(ctx, parseRe, n.CaseWhen.GetExpr(), n.CaseWhen.GetResult())
+	case *pg_query.Node_AArrayExpr:
+		return parseExpressions(ctx, parseRe, n.AArrayExpr.GetElements()...)
+	case *pg_query.Node_AIndirection:
+		if err := parseExpression(ctx, n.AIndirection.GetArg(), parseRe); err != nil {
+			return err
+		}
+		// field names and `*` in indirection are not column references,
+		// array subscripts can be any expression
+		for _, it := range n.AIndirection.GetIndirection() {
+			if indices := it.GetAIndices(); indices != nil {
+				if err := parseExpressions(ctx, parseRe, indices.GetLidx(), indices.GetUidx()); err != nil {
+					return err
 				}
 			}
 		}
+	case *pg_query.Node_List:
+		return parseExpressions(ctx, parseRe, n.List.GetItems()...)
+	case *pg_query.Node_SubLink:
+		return parseSublink(ctx, n.SubLink, parseRe)
+	case *pg_query.Node_WindowDef:
+		return parseWindowDef(ctx, n.WindowDef, parseRe)
+	case *pg_query.Node_SortBy:
+		return parseExpression(ctx, n.SortBy.GetNode(), parseRe)
+	case *pg_query.Node_ResTarget:
+		return parseExpression(ctx, n.ResTarget.GetVal(), parseRe)
+	case *pg_query.Node_MultiAssignRef:
+		return parseExpression(ctx, n.MultiAssignRef.GetSource(), parseRe)
+	case *pg_query.Node_JoinExpr, *pg_query.Node_RangeVar, *pg_query.Node_RangeSubselect:
+		return parseFromClause(ctx, clause, parseRe)
+	default:
+		// e.g. XmlExpr, JSON constructors and predicates
+		return parseExpressionChildren(ctx, clause, parseRe)
 	}
+	return nil
+}
 
+func parseExpressions(ctx VetContext, parseRe *ParseResult, clauses ...*pg_query.Node) error {
+	for _, clause := range clauses {
+		if err := parseExpression(ctx, clause, parseRe); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseExpressionChildren parses expressions nested in clause, including
+// ones inside fields that are not nodes themselves, e.g. TypeName.
+func parseExpressionChildren(ctx VetContext, clause *pg_query.Node, parseRe *ParseResult) error {
+	var err error
+	walkChildren(clause, func(n *pg_query.Node) bool {
+		if err == nil {
+			err = parseExpression(ctx, n, parseRe)
+		}
+		return false
+	})
+	return err
+}
+
+func parseSublink(ctx VetContext, sublink *pg_query.SubLink, parseRe *ParseResult) error {
+	// left side of IN, ANY and ALL belongs to outer query
+	if err := parseExpression(ctx, sublink.GetTestexpr(), parseRe); err != nil {
+		return err
+	}
+	queryParams, _, err := validateSelectStmt(ctx, sublink.GetSubselect().GetSelectStmt())
+	if err != nil {
+		return err
+	}
+	AddQueryParams(&parseRe.Params, queryParams)
 	return nil
 }
 
-func validateInsertValues(_ VetContext, _ []ColumnUsed, _ interface{}) error { return nil }
+// parseRangeSubselect validates a subquery in FROM clause and adds it to
+// parseRe.subqueries under its alias. Only LATERAL subqueries can refer to
+// FROM items listed before them.
+func parseRangeSubselect(ctx VetContext, clause *pg_query.Node, parseRe *ParseResult) error {
+	subselect := clause.GetRangeSubselect()
+	subCtx := ctx
+	if subselect.GetLateral() {
+		var err error
+		if subCtx, err = ctx.withFromItems(parseRe); err != nil {
+			return err
+		}
+	}
+	queryParams, _, err := validateSelectStmt(subCtx, subselect.GetSubquery().GetSelectStmt())
+	if err != nil {
+		return err
+	}
+	AddQueryParams(&parseRe.Params, queryParams)
 
-func parseWindowDef(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+	alias := subselect.GetAlias()
+	if alias.GetAliasname() == "" {
+		return nil
+	}
+	cols, ok := stmtColumns(subCtx, subselect.GetSubquery(), subCtx.cteColumns())
+	if !ok {
+		cols = nil
+	}
+	parseRe.subqueries = append(parseRe.subqueries, relation{
+		Name:    alias.GetAliasname(),
+		Columns: aliasColumns(alias.GetColnames(), cols),
+	})
+	return nil
+}
 
-// recursive function to parse expressions including nested expressions
-func parseExpression(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+// parseRangeFunction parses arguments of functions in FROM clause, e.g.
+// generate_series(1, $1) AS g(n), and adds them to parseRe.subqueries with
+// unknown columns
+func parseRangeFunction(ctx VetContext, rf *pg_query.RangeFunction, parseRe *ParseResult) error {
+	name := rf.GetAlias().GetAliasname()
+	for _, f := range rf.GetFunctions() {
+		// function call followed by its column definition list
+		items := f.GetList().GetItems()
+		if len(items) == 0 {
+			continue
+		}
+		if err := parseExpression(ctx, items[0], parseRe); err != nil {
+			return err
+		}
+		if funcName := items[0].GetFuncCall().GetFuncname(); name == "" && len(funcName) > 0 {
+			name = funcName[len(funcName)-1].GetString_().GetSval()
+		}
+	}
+	if name != "" {
+		parseRe.subqueries = append(parseRe.subqueries, relation{Name: name})
+	}
+	return nil
+}
 
-func parseSublink(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+func parseJoinExpr(ctx VetContext, joinExpr *pg_query.JoinExpr, parseRe *ParseResult) error {
+	tables, subqueries := len(parseRe.Tables), len(parseRe.subqueries)
+	if err := parseFromClause(ctx, joinExpr.GetLarg(), parseRe); err != nil {
+		return err
+	}
+	left := &ParseResult{Tables: parseRe.Tables[tables:], subqueries: parseRe.subqueries[subqueries:]}
+	tables, subqueries = len(parseRe.Tables), len(parseRe.subqueries)
+	if err := parseFromClause(ctx, joinExpr.GetRarg(), parseRe); err != nil {
+		return err
+	}
+	right := &ParseResult{Tables: parseRe.Tables[tables:], subqueries: parseRe.subqueries[subqueries:]}
 
-func parseRangeSubselect(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+	for _, u := range joinExpr.GetUsingClause() {
+		for _, side := range []*ParseResult{left, right} {
+			if err := parseUsingColumn(ctx, u.GetString_().GetSval(), side, parseRe); err != nil {
+				return err
+			}
+		}
+	}
 
-func parseJoinExpr(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+	// subqueries in join condition can refer to the joined relations
+	joinCtx, err := ctx.withFromItems(parseRe)
+	if err != nil {
+		return err
+	}
+	return parseExpression(joinCtx, joinExpr.GetQuals(), parseRe)
+}
+
+// parseUsingColumn adds JOIN USING column as used by the relation on one side
+// of the join. A side joining several relations only needs one of them to
+// have the column, so it is checked right away.
+func parseUsingColumn(ctx VetContext, column string, side *ParseResult, parseRe *ParseResult) error {
+	if len(side.Tables)+len(side.subqueries) == 1 {
+		col := ColumnUsed{Column: column}
+		if len(side.Tables) == 1 {
+			t := side.Tables[0]
+			col.Table, col.Schema = t.Name, t.Schema
+			if t.Alias != "" {
+				col.Table, col.Schema = t.Alias, ""
+			}
+		} else {
+			col.Table = side.subqueries[0].Name
+		}
+		parseRe.Columns = append(parseRe.Columns, col)
+		return nil
+	}
+
+	sideCtx, err := ctx.withFromItems(side)
+	if err != nil || ctx.Schema.Tables == nil {
+		return err
+	}
+	for _, r := range sideCtx.scope.relations {
+		if r.hasColumn(column) {
+			return nil
+		}
+	}
+	return fmt.Errorf("column `%s` is not defined in any of the joined tables", column)
+}
 
 // find used column names from where clause
-func parseWhereClause(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+func parseWhereClause(ctx VetContext, clause *pg_query.Node, parseRe *ParseResult) error {
+	if err := parseExpression(ctx, clause, parseRe); err != nil {
+		return fmt.Errorf("invalid WHERE clause: %w", err)
+	}
+	return nil
+}
+
+// parseFromItems parses FROM clause of SELECT and UPDATE, or USING clause of
+// DELETE, into one result so LATERAL subqueries can refer to items listed
+// before them
+func parseFromItems(ctx VetContext, items []*pg_query.Node, parseRe *ParseResult) error {
+	for _, item := range items {
+		if err := parseFromClause(ctx, item, parseRe); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSelectClauses parses DISTINCT ON, GROUP BY, HAVING, WINDOW, ORDER BY,
+// LIMIT and OFFSET clauses of a SELECT
+func parseSelectClauses(ctx VetContext, stmt *pg_query.SelectStmt, parseRe *ParseResult) error {
+	outputNames := map[string]bool{}
+	for _, target := range stmt.GetTargetList() {
+		if name := target.GetResTarget().GetName(); name != "" {
+			outputNames[name] = true
+		}
+	}
+	clauses := []*pg_query.Node{stmt.GetHavingClause(), stmt.GetLimitCount(), stmt.GetLimitOffset()}
+	clauses = append(clauses, stmt.GetDistinctClause()...)
+	clauses = append(clauses, withoutOutputNames(stmt.GetGroupClause(), outputNames)...)
+	clauses = append(clauses, stmt.GetWindowClause()...)
+	clauses = append(clauses, withoutOutputNames(stmt.GetSortClause(), outputNames)...)
+	return parseExpressions(ctx, parseRe, clauses...)
+}
+
+// withoutOutputNames filters out GROUP BY and ORDER BY items referring to
+// output columns by name, e.g. `ORDER BY total` for `SELECT sum(count) AS
+// total`
+func withoutOutputNames(items []*pg_query.Node, outputNames map[string]bool) []*pg_query.Node {
+	exprs := []*pg_query.Node{}
+	for _, item := range items {
+		expr := item
+		if sortBy := item.GetSortBy(); sortBy != nil {
+			expr = sortBy.GetNode()
+		}
+		if fields := expr.GetColumnRef().GetFields(); len(fields) == 1 && outputNames[fields[0].GetString_().GetSval()] {
+			continue
+		}
+		exprs = append(exprs, item)
+	}
+	return exprs
+}
+
+func validateSelectStmt(ctx VetContext, stmt *pg_query.SelectStmt) ([]QueryParam, []ColumnUsed, error) {
+	ctx = ctx.enterScope()
+	usedCols := []ColumnUsed{}
+	queryParams := []QueryParam{}
+
+	if stmt.GetWithClause() != nil {
+		params, err := parseCTE(ctx, stmt.GetWithClause())
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, params)
+	}
+
+	// UNION, INTERSECT and EXCEPT, each side is a query level of its own
+	if stmt.GetOp() != pg_query.SetOperation_SETOP_NONE {
+		for _, arm := range []*pg_query.SelectStmt{stmt.GetLarg(), stmt.GetRarg()} {
+			params, cols, err := validateSelectStmt(ctx, arm)
+			if err != nil {
+				return nil, nil, err
+			}
+			AddQueryParams(&queryParams, params)
+			usedCols = append(usedCols, cols...)
+		}
+		// ORDER BY can only refer to output columns by name or position
+		re := &ParseResult{}
+		if err := parseExpressions(ctx, re, stmt.GetLimitCount(), stmt.GetLimitOffset()); err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, re.Params)
+		return queryParams, usedCols, nil
+	}
+
+	// FROM/JOIN tables
+	re := &ParseResult{}
+	if err := parseFromItems(ctx, stmt.GetFromClause(), re); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+	if err := ctx.addRelations(re.Tables, re.subqueries); err != nil {
+		return nil, nil, err
+	}
 
-func parseUsingClause(_ VetContext, _ interface{}, _ *ParseResult) error { return nil }
+	// VALUES lists, e.g. in FROM (VALUES ...) AS v
+	re = &ParseResult{}
+	if err := parseExpressions(ctx, re, stmt.GetValuesLists()...); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
 
-func getUsedColumnsFromNodeList(_ interface{}) []ColumnUsed { return []ColumnUsed{} }
+	for _, target := range stmt.GetTargetList() {
+		re := &ParseResult{}
+		if err := parseExpression(ctx, target.GetResTarget().GetVal(), re); err != nil {
+			return nil, nil, err
+		}
+		usedCols = append(usedCols, re.Columns...)
+		AddQueryParams(&queryParams, re.Params)
+	}
 
-func getUsedColumnsFromSortClause(_ interface{}) []ColumnUsed { return []ColumnUsed{} }
+	if stmt.GetWhereClause() != nil {
+		re := &ParseResult{}
+		if err := parseWhereClause(ctx, stmt.GetWhereClause(), re); err != nil {
+			return nil, nil, err
+		}
+		usedCols = append(usedCols, re.Columns...)
+		AddQueryParams(&queryParams, re.Params)
+	}
 
-func validateSelectStmt(_ VetContext, _ interface{}) ([]QueryParam, []schema.Column, error) {
-	return nil, nil, fmt.Errorf("not used")
+	re = &ParseResult{}
+	if err := parseSelectClauses(ctx, stmt, re); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+
+	return queryParams, usedCols, ctx.validateColumns(usedCols)
 }
 
-func validateUpdateStmt(_ VetContext, _ interface{}) ([]QueryParam, []ColumnUsed, error) {
-	return nil, nil, fmt.Errorf("not used")
+func validateUpdateStmt(ctx VetContext, stmt *pg_query.UpdateStmt) ([]QueryParam, []ColumnUsed, error) {
+	ctx = ctx.enterScope()
+	queryParams := []QueryParam{}
+	if stmt.GetWithClause() != nil {
+		params, err := parseCTE(ctx, stmt.GetWithClause())
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, params)
+	}
+
+	target := rangeVarToTableUsed(stmt.GetRelation())
+	if err := validateTable(ctx, target, true); err != nil {
+		return nil, nil, err
+	}
+	table, _ := ctx.lookupTable(target)
+	// aliased target is only visible under its alias
+	tableName := target.Name
+	if target.Alias != "" {
+		tableName = target.Alias
+	}
+
+	// tables and subqueries in FROM clause are visible to SET and WHERE
+	re := &ParseResult{}
+	if err := parseFromItems(ctx, stmt.GetFromClause(), re); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.addRelations(append([]TableUsed{target}, re.Tables...), re.subqueries); err != nil {
+		return nil, nil, err
+	}
+	usedCols := append([]ColumnUsed{}, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+
+	for _, n := range stmt.GetTargetList() {
+		resTarget := n.GetResTarget()
+		if resTarget == nil {
+			continue
+		}
+		usedCols = append(usedCols, ColumnUsed{
+			Table:    tableName,
+			Column:   resTarget.GetName(),
+			Location: resTarget.GetLocation(),
+		})
+		re := &ParseResult{}
+		// subscripts of array targets, e.g. SET tags[$1] = $2
+		if err := parseExpressions(ctx, re, append([]*pg_query.Node{resTarget.GetVal()}, resTarget.GetIndirection()...)...); err != nil {
+			return nil, nil, err
+		}
+		usedCols = append(usedCols, re.Columns...)
+		AddQueryParams(&queryParams, re.Params)
+	}
+
+	if stmt.GetWhereClause() != nil {
+		re := &ParseResult{}
+		if err := parseWhereClause(ctx, stmt.GetWhereClause(), re); err != nil {
+			return nil, nil, err
+		}
+		usedCols = append(usedCols, re.Columns...)
+		AddQueryParams(&queryParams, re.Params)
+	}
+
+	re = &ParseResult{}
+	if err := parseExpressions(ctx, re, stmt.GetReturningList()...); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+	if err := ctx.validateColumns(usedCols); err != nil {
+		return nil, nil, err
+	}
+	if err := validateTargetValues(table, stmt.GetTargetList()); err != nil {
+		return nil, nil, err
+	}
+	return queryParams, usedCols, nil
 }
 
-func validateInsertStmt(_ VetContext, _ interface{}) ([]QueryParam, []ColumnUsed, error) {
-	return nil, nil, fmt.Errorf("not used")
+func validateInsertStmt(ctx VetContext, stmt *pg_query.InsertStmt) ([]QueryParam, []ColumnUsed, error) {
+	ctx = ctx.enterScope()
+	queryParams := []QueryParam{}
+	if stmt.GetWithClause() != nil {
+		params, err := parseCTE(ctx, stmt.GetWithClause())
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, params)
+	}
+
+	target := rangeVarToTableUsed(stmt.GetRelation())
+	if err := validateTable(ctx, target, true); err != nil {
+		return nil, nil, err
+	}
+	table, _ := ctx.lookupTable(target)
+	// aliased target is only visible under its alias
+	tableName := target.Name
+	if target.Alias != "" {
+		tableName = target.Alias
+	}
+
+	targetCols := []ColumnUsed{}
+	for _, n := range stmt.GetCols() {
+		resTarget := n.GetResTarget()
+		if resTarget == nil {
+			continue
+		}
+		targetCols = append(targetCols, ColumnUsed{
+			Table:    tableName,
+			Column:   resTarget.GetName(),
+			Location: resTarget.GetLocation(),
+		})
+	}
+
+	values := []*pg_query.Node{}
+	usedCols := append([]ColumnUsed{}, targetCols...)
+
+	selectStmt := stmt.GetSelectStmt().GetSelectStmt()
+	if selectStmt == nil {
+		// DEFAULT VALUES sets every column to its default
+		if err := validateDefaultedColumns(table, map[string]bool{}); err != nil {
+			return nil, nil, err
+		}
+	} else if len(selectStmt.GetValuesLists()) > 0 {
+		for _, list := range selectStmt.GetValuesLists() {
+			items := list.GetList().GetItems()
+			if len(items) != len(targetCols) {
+				return nil, nil, fmt.Errorf(
+					"column count %d doesn't match value count %d",
+					len(targetCols), len(items),
+				)
+			}
+			for _, item := range items {
+				re := &ParseResult{}
+				if err := parseExpression(ctx, item, re); err != nil {
+					return nil, nil, fmt.Errorf("invalid value list: %w", err)
+				}
+				usedCols = append(usedCols, re.Columns...)
+				AddQueryParams(&queryParams, re.Params)
+				values = append(values, item)
+			}
+		}
+	} else {
+		// source query is a query level of its own
+		qp, _, err := validateSelectStmt(ctx, selectStmt)
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, qp)
+		// values of UNION, INTERSECT and EXCEPT can't be matched with
+		// columns, their target lists are empty
+		for _, n := range selectStmt.GetTargetList() {
+			values = append(values, n.GetResTarget().GetVal())
+		}
+	}
+
+	// target table is not visible to SELECT source
+	if err := ctx.addRelations([]TableUsed{target}, nil); err != nil {
+		return nil, nil, err
+	}
+
+	if onConflict := stmt.GetOnConflictClause(); onConflict != nil {
+		params, err := parseOnConflictClause(ctx, target, onConflict)
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, params)
+	}
+
+	re := &ParseResult{}
+	if err := parseExpressions(ctx, re, stmt.GetReturningList()...); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+	if err := ctx.validateColumns(usedCols); err != nil {
+		return nil, nil, err
+	}
+	if len(targetCols) > 0 && len(values)%len(targetCols) != 0 {
+		// e.g. SELECT * as source, values can't be matched with columns
+		values = nil
+	}
+	overriding := stmt.GetOverride() == pg_query.OverridingKind_OVERRIDING_SYSTEM_VALUE
+	if err := validateInsertColumns(table, targetCols, values, overriding); err != nil {
+		return nil, nil, err
+	}
+	if err := validateInsertValues(table, targetCols, values); err != nil {
+		return nil, nil, err
+	}
+	if err := validateTargetValues(table, stmt.GetOnConflictClause().GetTargetList()); err != nil {
+		return nil, nil, err
+	}
+	return queryParams, usedCols, nil
 }
 
-func validateDeleteStmt(_ VetContext, _ interface{}) ([]QueryParam, []ColumnUsed, error) {
-	return nil, nil, fmt.Errorf("not used")
+func validateDeleteStmt(ctx VetContext, stmt *pg_query.DeleteStmt) ([]QueryParam, []ColumnUsed, error) {
+	ctx = ctx.enterScope()
+	queryParams := []QueryParam{}
+	if stmt.GetWithClause() != nil {
+		params, err := parseCTE(ctx, stmt.GetWithClause())
+		if err != nil {
+			return nil, nil, err
+		}
+		AddQueryParams(&queryParams, params)
+	}
+
+	target := rangeVarToTableUsed(stmt.GetRelation())
+	if err := validateTable(ctx, target, true); err != nil {
+		return nil, nil, err
+	}
+
+	usedCols := []ColumnUsed{}
+
+	if stmt.GetWhereClause() == nil {
+		return nil, nil, fmt.Errorf("no WHERE clause for DELETE")
+	}
+
+	// tables and subqueries in USING clause are visible to WHERE
+	re := &ParseResult{}
+	if err := parseFromItems(ctx, stmt.GetUsingClause(), re); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.addRelations(append([]TableUsed{target}, re.Tables...), re.subqueries); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+
+	re = &ParseResult{}
+	if err := parseWhereClause(ctx, stmt.GetWhereClause(), re); err != nil {
+		return nil, nil, err
+	}
+	if len(re.Columns) == 0 {
+		return nil, nil, fmt.Errorf("no columns in DELETE's WHERE clause")
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+
+	re = &ParseResult{}
+	if err := parseExpressions(ctx, re, stmt.GetReturningList()...); err != nil {
+		return nil, nil, err
+	}
+	usedCols = append(usedCols, re.Columns...)
+	AddQueryParams(&queryParams, re.Params)
+	if err := ctx.validateColumns(usedCols); err != nil {
+		return nil, nil, err
+	}
+	return queryParams, usedCols, nil
 }
 
-func parseCTE(_ VetContext, _ interface{}) error { return nil }
+// parseCTE validates CTE queries and makes their names visible to current
+// query level, parameters used by CTE queries are returned
+func parseCTE(ctx VetContext, with *pg_query.WithClause) ([]QueryParam, error) {
+	queryParams := []QueryParam{}
+	for _, n := range with.GetCtes() {
+		cte := n.GetCommonTableExpr()
+		if cte == nil {
+			continue
+		}
+		if with.GetRecursive() {
+			// self references see columns as unknown
+			ctx.scope.ctes[cte.GetCtename()] = nil
+		}
+		params, _, err := validateSqlQuery(ctx, cte.GetCtequery())
+		if err != nil {
+			return nil, err
+		}
+		AddQueryParams(&queryParams, params)
+		ctx.scope.ctes[cte.GetCtename()] = cteColumns(ctx, cte, ctx.cteColumns())
+	}
+	return queryParams, nil
+}
 
-func ValidateSqlQuery(ctx VetContext, queryStr string) ([]QueryParam, error) {
-	j, err := pg_wasm.ParseToJSON(queryStr)
-	if err != nil {
+// parseOnConflictClause validates ON CONFLICT target and DO UPDATE clauses,
+// row proposed for insertion is available to them as `excluded`
+func parseOnConflictClause(ctx VetContext, target TableUsed, onConflict *pg_query.OnConflictClause) ([]QueryParam, error) {
+	ctx = ctx.enterScope()
+	excluded := TableUsed{Schema: target.Schema, Name: target.Name, Alias: "excluded"}
+	if err := ctx.addRelations([]TableUsed{excluded}, nil); err != nil {
 		return nil, err
 	}
-	root, err := parseJSONTree(j)
-	if err != nil {
+	infer := onConflict.GetInfer()
+	clauses := []*pg_query.Node{infer.GetWhereClause(), onConflict.GetWhereClause()}
+	clauses = append(clauses, infer.GetIndexElems()...)
+	clauses = append(clauses, onConflict.GetTargetList()...)
+	re := &ParseResult{}
+	if err := parseExpressions(ctx, re, clauses...); err != nil {
 		return nil, err
 	}
-	params, _, err := jsonValidateQuery(ctx, root)
+	return re.Params, ctx.validateColumns(re.Columns)
+}
+
+func ValidateSqlQuery(ctx VetContext, queryStr string) ([]QueryParam, error) {
+	params, _, err := validateSqlQueryStmt(ctx, queryStr)
 	return params, err
 }
 
-func ValidateSqlQueries(ctx VetContext, queryStr string) ([][]QueryParam, error) {
-	j, err := pg_wasm.ParseToJSON(queryStr)
+// validateSqlQueryStmt validates a single statement query, parsed statement
+// is returned for further analysis.
+func validateSqlQueryStmt(ctx VetContext, queryStr string) ([]QueryParam, *pg_query.Node, error) {
+	tree, err := parseutil.Parse(queryStr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	root, err := parseJSONTree(j)
+	params, _, err := validateRawStmts(ctx, tree.GetStmts())
+	if err != nil {
+		return params, nil, err
+	}
+	return params, tree.GetStmts()[0].GetStmt(), nil
+}
+
+func ValidateSqlQueries(ctx VetContext, queryStr string) ([][]QueryParam, error) {
+	tree, err := parseutil.Parse(queryStr)
 	if err != nil {
 		return nil, err
 	}
-	stmts := asList(root["stmts"])
 	var out [][]QueryParam
-	for _, s := range stmts {
-		one := map[string]any{"stmts": []any{s}}
-		qp, _, err := jsonValidateQuery(ctx, one)
+	for _, stmt := range tree.GetStmts() {
+		qp, _, err := validateRawStmts(ctx, []*pg_query.RawStmt{stmt})
 		if err != nil {
 			return nil, err
 		}
@@ -274,6 +995,35 @@ func ValidateSqlQueries(ctx VetContext, queryStr string) ([][]QueryParam, error)
 	return out, nil
 }
 
-func validateSqlQuery(_ VetContext, _ any) ([]QueryParam, []ColumnUsed, error) {
-	return nil, nil, fmt.Errorf("not used")
+func validateRawStmts(ctx VetContext, stmts []*pg_query.RawStmt) ([]QueryParam, []ColumnUsed, error) {
+	if len(stmts) == 0 {
+		return nil, nil, errors.New("empty statement")
+	}
+	if len(stmts) > 1 {
+		return nil, nil, fmt.Errorf("query contained more than one statement")
+	}
+	stmt := stmts[0].GetStmt()
+	queryParams, usedCols, err := validateSqlQuery(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := inferParamTypes(ctx, stmt, queryParams); err != nil {
+		return nil, nil, err
+	}
+	return queryParams, usedCols, nil
+}
+
+func validateSqlQuery(ctx VetContext, node *pg_query.Node) ([]QueryParam, []ColumnUsed, error) {
+	switch stmt := node.GetNode().(type) {
+	case *pg_query.Node_SelectStmt:
+		return validateSelectStmt(ctx, stmt.SelectStmt)
+	case *pg_query.Node_UpdateStmt:
+		return validateUpdateStmt(ctx, stmt.UpdateStmt)
+	case *pg_query.Node_InsertStmt:
+		return validateInsertStmt(ctx, stmt.InsertStmt)
+	case *pg_query.Node_DeleteStmt:
+		return validateDeleteStmt(ctx, stmt.DeleteStmt)
+	default:
+		return nil, nil, fmt.Errorf("unsupported statement: %s", nodeKind(node))
+	}
 }
@@ -0,0 +1,66 @@
+package vet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	pg_wasm "github.com/wasilibs/go-pgquery"
+)
+
+func TestParseExprNodes(t *testing.T) {
+	tests := []struct {
+		expr   string
+		cols   []string
+		params []int32
+	}{
+		{"a = $1 AND b = $2 OR NOT c", []string{"a", "b", "c"}, []int32{1, 2}},
+		{"a IN ($1, b)", []string{"a", "b"}, []int32{1}},
+		{"a BETWEEN b AND $1", []string{"a", "b"}, []int32{1}},
+		{"a = ANY($1)", []string{"a"}, []int32{1}},
+		{"NULLIF(a, $1)", []string{"a"}, []int32{1}},
+		{"COALESCE($1, a, b)", []string{"a", "b"}, []int32{1}},
+		{"GREATEST(a, $1, b)", []string{"a", "b"}, []int32{1}},
+		{"ROW(a, $1) = ROW(b, $2)", []string{"a", "b"}, []int32{1, 2}},
+		{"ARRAY[a, $1, b]", []string{"a", "b"}, []int32{1}},
+		{"(a).field", []string{"a"}, nil},
+		{"b[$1:c]", []string{"b", "c"}, []int32{1}},
+		{`a COLLATE "C"`, []string{"a"}, nil},
+		{"a IS TRUE", []string{"a"}, nil},
+		{"a IS NOT NULL", []string{"a"}, nil},
+		{"CASE a WHEN $1 THEN b ELSE c END", []string{"a", "b", "c"}, []int32{1}},
+		{"CASE WHEN a > $1 THEN b END", []string{"a", "b"}, []int32{1}},
+		{"$1::int + a", []string{"a"}, []int32{1}},
+		{"lower(a) || upper(b)", []string{"a", "b"}, nil},
+		{"concat_ws(',', a, b, $1)", []string{"a", "b"}, []int32{1}},
+		{"f(x => a)", []string{"a"}, nil},
+		{"count(a) FILTER (WHERE b > $1)", []string{"a", "b"}, []int32{1}},
+		{"string_agg(a, ',' ORDER BY b)", []string{"a", "b"}, nil},
+		{"sum(a) OVER (PARTITION BY b, c ORDER BY d, e ROWS BETWEEN $1 PRECEDING AND CURRENT ROW)", []string{"a", "b", "c", "d", "e"}, []int32{1}},
+		{"a IN (SELECT 1 WHERE 2 = $1)", []string{"a"}, []int32{1}},
+		{"EXISTS (SELECT 1 WHERE 2 = $1)", nil, []int32{1}},
+		{"xmlelement(name foo, xmlattributes(a AS bar), b)", []string{"a", "b"}, nil},
+		{"json_object('k' VALUE a)", []string{"a"}, nil},
+		{"a IS JSON", []string{"a"}, nil},
+		{"CURRENT_DATE", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			tree, err := pg_wasm.Parse("SELECT " + tt.expr)
+			require.NoError(t, err)
+			target := tree.GetStmts()[0].GetStmt().GetSelectStmt().GetTargetList()[0].GetResTarget()
+
+			re := &ParseResult{}
+			require.NoError(t, parseExpression(VetContext{}, target.GetVal(), re))
+			cols := []string{}
+			for _, c := range re.Columns {
+				cols = append(cols, c.Column)
+			}
+			params := []int32{}
+			for _, p := range re.Params {
+				params = append(params, p.Number)
+			}
+			require.ElementsMatch(t, tt.cols, cols)
+			require.ElementsMatch(t, tt.params, params)
+		})
+	}
+}
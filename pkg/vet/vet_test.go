@@ -1,16 +1,25 @@
 package vet_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	pg_wasm "github.com/wasilibs/go-pgquery"
 
+	"github.com/houqp/sqlvet/pkg/parseutil"
 	"github.com/houqp/sqlvet/pkg/schema"
 	"github.com/houqp/sqlvet/pkg/vet"
 )
 
+var mockOrderStatus = &schema.Enum{
+	Name:   "order_status",
+	Schema: "public",
+	Values: []string{"pending", "shipped", "delivered"},
+}
+
 var mockDbSchema = &schema.Db{
 	Tables: map[string]schema.Table{
 		"foo": {
@@ -55,6 +64,42 @@ var mockDbSchema = &schema.Db{
 			},
 			ReadOnly: true,
 		},
+		"qux": {
+			Name: "qux",
+			Columns: map[string]schema.Column{
+				"id":         {Name: "id", Type: "pg_catalog.int2"},
+				"active":     {Name: "active", Type: "pg_catalog.bool"},
+				"uid":        {Name: "uid", Type: "uuid"},
+				"day":        {Name: "day", Type: "date"},
+				"created_at": {Name: "created_at", Type: "timestamptz"},
+				"amount":     {Name: "amount", Type: "pg_catalog.numeric"},
+				"status":     {Name: "status", Type: "order_status", Enum: mockOrderStatus},
+			},
+		},
+		"users": {
+			Name: "users",
+			Columns: map[string]schema.Column{
+				"id": {
+					Name: "id", Type: "pg_catalog.int8",
+					NotNull: true, HasDefault: true, Identity: true, GeneratedAlways: true,
+				},
+				"name":     {Name: "name", Type: "text", NotNull: true},
+				"email":    {Name: "email", Type: "text", NotNull: true, HasDefault: true},
+				"nickname": {Name: "nickname", Type: "text"},
+				"name_upper": {
+					Name: "name_upper", Type: "text",
+					HasDefault: true, GeneratedAlways: true,
+				},
+			},
+		},
+		"auth.users": {
+			Name:   "users",
+			Schema: "auth",
+			Columns: map[string]schema.Column{
+				"id":       {Name: "id", Type: "pg_catalog.int8"},
+				"password": {Name: "password", Type: "text"},
+			},
+		},
 	},
 }
 
@@ -71,6 +116,16 @@ func TestInsert(t *testing.T) {
 			"insert",
 			`INSERT INTO foo (id) VALUES (1)`,
 		},
+		{
+			"insert default values",
+			`INSERT INTO foo DEFAULT VALUES RETURNING id`,
+		},
+		{
+			"insert with select cte",
+			`INSERT INTO foo (id, value)
+			WITH x AS (SELECT 1 AS id, 'a' AS value)
+			SELECT id, value FROM x`,
+		},
 		{
 			"insert with select",
 			`INSERT INTO foo (id)
@@ -101,6 +156,33 @@ func TestInsert(t *testing.T) {
 			"insert with return",
 			`INSERT INTO foo (id) VALUES (1) RETURNING value`,
 		},
+		{
+			"insert typed literals",
+			`INSERT INTO qux (id, active, uid, day, created_at, amount) VALUES
+			(1, true, 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', '2024-02-29', '2024-02-29 10:00:00+00', 1.5),
+			('-32768', 'yes', '{A0EEBC999C0B4EF8BB6D6BB9BD380A11}', 'today', 'now', '1e3'),
+			(NULL, NULL, NULL, NULL, NULL, NULL)`,
+		},
+		{
+			"insert enum label",
+			`INSERT INTO qux (id, status) VALUES (1, 'pending'), (2, NULL)`,
+		},
+		{
+			"insert omitting columns with default",
+			`INSERT INTO users (name, nickname) VALUES ('a', NULL)`,
+		},
+		{
+			"insert into generated columns with default",
+			`INSERT INTO users (id, name, name_upper) VALUES (DEFAULT, 'a', DEFAULT)`,
+		},
+		{
+			"insert into identity column overriding system value",
+			`INSERT INTO users (id, name) OVERRIDING SYSTEM VALUE VALUES (1, 'a')`,
+		},
+		{
+			"insert into schema qualified table",
+			`INSERT INTO auth.users (id, password) VALUES (1, 'secret') RETURNING auth.users.id`,
+		},
 		{
 			"insert with coalesce expr",
 			`INSERT INTO foo (
@@ -169,6 +251,71 @@ func TestInvalidInsert(t *testing.T) {
 			`INSERT INTO foo (id) VALUES (oops+1)`,
 			errors.New("column `oops` is not defined in table `foo`"),
 		},
+		{
+			"string into integer column",
+			`INSERT INTO foo (id, value) VALUES ('abc', 'abc')`,
+			errors.New("invalid value 'abc' for column `id` of type int"),
+		},
+		{
+			"integer into boolean column",
+			`INSERT INTO qux (id, active) VALUES (1, 1)`,
+			errors.New("invalid value 1 for column `active` of type bool"),
+		},
+		{
+			"non-boolean string into boolean column",
+			`INSERT INTO qux (active) VALUES ('maybe')`,
+			errors.New("invalid value 'maybe' for column `active` of type bool"),
+		},
+		{
+			"out of range for smallint in second row",
+			`INSERT INTO qux (id, active) VALUES (1, true), (40000, false)`,
+			errors.New("value 40000 is out of range for column `id` of type int2"),
+		},
+		{
+			"malformed uuid",
+			`INSERT INTO qux (uid) VALUES ('a0eebc99-9c0b-4ef8-bb6d')`,
+			errors.New("invalid value 'a0eebc99-9c0b-4ef8-bb6d' for column `uid` of type uuid"),
+		},
+		{
+			"malformed date",
+			`INSERT INTO qux (day) VALUES ('2024-02-30')`,
+			errors.New("invalid value '2024-02-30' for column `day` of type date"),
+		},
+		{
+			"malformed value from select",
+			`INSERT INTO qux (id, amount) SELECT bar.id, 'lots' FROM bar`,
+			errors.New("invalid value 'lots' for column `amount` of type numeric"),
+		},
+		{
+			"invalid enum label",
+			`INSERT INTO qux (id, status) VALUES (1, 'cancelled')`,
+			errors.New("invalid value 'cancelled' for column `status` of type order_status"),
+		},
+		{
+			"missing NOT NULL column",
+			`INSERT INTO users (email, nickname) VALUES ('a', 'b')`,
+			errors.New("missing value for NOT NULL column `name` in table `users`"),
+		},
+		{
+			"default values for NOT NULL column",
+			`INSERT INTO users DEFAULT VALUES`,
+			errors.New("missing value for NOT NULL column `name` in table `users`"),
+		},
+		{
+			"NULL into NOT NULL column",
+			`INSERT INTO users (name, email) VALUES ('a', 'b'), (NULL, 'c')`,
+			errors.New("null value in column `name` violates NOT NULL constraint"),
+		},
+		{
+			"into GENERATED ALWAYS identity column",
+			`INSERT INTO users (id, name) VALUES (1, 'a')`,
+			errors.New("cannot insert into GENERATED ALWAYS column `id`"),
+		},
+		{
+			"into generated column",
+			`INSERT INTO users (name, name_upper) VALUES ('a', DEFAULT), ('b', 'B')`,
+			errors.New("cannot insert into GENERATED ALWAYS column `name_upper`"),
+		},
 		{
 			"invalid table from select",
 			`INSERT INTO foo (id, value)
@@ -199,9 +346,12 @@ func TestInvalidInsert(t *testing.T) {
 			SELECT bar.id, (SELECT 'test' FROM bar WHERE ida = 1)
 			FROM bar
 			WHERE bar.id=2`,
-			fmt.Errorf(
-				"invalid SELECT query in value list: %w",
-				errors.New("column `ida` is not defined in table `bar`")),
+			errors.New("column `ida` is not defined in table `bar`"),
+		},
+		{
+			"invalid column of target in select",
+			`INSERT INTO foo (id, value) SELECT id, value FROM bar`,
+			errors.New("column `value` is not defined in table `bar`"),
 		},
 		{
 			"invalid table from select join",
@@ -241,6 +391,16 @@ func TestInvalidInsert(t *testing.T) {
 			`INSERT INTO foo (id) VALUES (1) RETURNING uid`,
 			errors.New("column `uid` is not defined in table `foo`"),
 		},
+		{
+			"insert with invalid column in returning expression",
+			`INSERT INTO foo (id) VALUES (1) RETURNING lower(uid)`,
+			errors.New("column `uid` is not defined in table `foo`"),
+		},
+		{
+			"insert with invalid excluded column",
+			`INSERT INTO foo (id) VALUES (1) ON CONFLICT (id) DO UPDATE SET value = excluded.oops`,
+			errors.New("column `oops` is not defined in table `excluded`"),
+		},
 	}
 
 	for _, tcase := range testCases {
@@ -275,6 +435,31 @@ func TestInvalidSelect(t *testing.T) {
 			`SELECT foononexist.id FROM foo`,
 			errors.New("table `foononexist` not available for query"),
 		},
+		{
+			"invalid enum label in comparison",
+			`SELECT id FROM qux WHERE 'delivered' = status OR qux.status IN ('pending', 'lost')`,
+			errors.New("invalid value 'lost' for column `status` of type order_status"),
+		},
+		{
+			"unknown function",
+			`SELECT lenght(value) FROM foo`,
+			errors.New("function `lenght` does not exist"),
+		},
+		{
+			"wrong function argument count",
+			`SELECT id FROM foo WHERE lower(value, 'x') = 'a'`,
+			errors.New("invalid WHERE clause: function `lower` expects 1 argument, but received 2"),
+		},
+		{
+			"invalid schema",
+			`SELECT id FROM nosuch.users`,
+			errors.New("invalid table name: nosuch.users"),
+		},
+		{
+			"column of table in other schema",
+			`SELECT public.users.password FROM auth.users`,
+			errors.New("table `public.users` not available for query"),
+		},
 		{
 			"invalid target column",
 			`SELECT id, date, value FROM foo`,
@@ -290,6 +475,36 @@ func TestInvalidSelect(t *testing.T) {
 			`SELECT id, value FROM foo WHERE date IS NULL`,
 			errors.New("column `date` is not defined in table `foo`"),
 		},
+		{
+			"invalid column in second boolean operand",
+			`SELECT id FROM foo WHERE id = $1 AND bogus_col = $2`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in case expression",
+			`SELECT CASE WHEN id > 1 THEN bogus_col ELSE value END FROM foo`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in function argument",
+			`SELECT coalesce(value, bogus_col) FROM foo`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in aggregate filter",
+			`SELECT count(*) FILTER (WHERE bogus_col > 1) FROM foo`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in IN list",
+			`SELECT id FROM foo WHERE id IN (1, bogus_col)`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in array subscript",
+			`SELECT (ARRAY[id])[bogus_col] FROM foo`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
 		{
 			"invalid table in join",
 			`SELECT id, value FROM foo JOIN barr ON foo.id=barr.id`,
@@ -311,6 +526,21 @@ func TestInvalidSelect(t *testing.T) {
 			WHERE value IS NULL`,
 			errors.New("column `date` is not defined in table `b`"),
 		},
+		{
+			"invalid column in join using",
+			`SELECT 1 FROM foo JOIN bar USING (bogus)`,
+			errors.New("column `bogus` is not defined in table `foo`"),
+		},
+		{
+			"join using column missing on right side",
+			`SELECT 1 FROM foo f JOIN bar b USING (value)`,
+			errors.New("column `value` is not defined in table `b`"),
+		},
+		{
+			"join using column missing in nested join",
+			`SELECT 1 FROM foo JOIN bar USING (id) JOIN baz USING (created_at)`,
+			errors.New("column `created_at` is not defined in any of the joined tables"),
+		},
 		{
 			"invalid column in order by",
 			`SELECT id, value FROM foo ORDER BY oops`,
@@ -321,11 +551,26 @@ func TestInvalidSelect(t *testing.T) {
 			`SELECT id, value FROM foo ORDER BY id, oops`,
 			errors.New("column `oops` is not defined in table `foo`"),
 		},
+		{
+			"invalid column in order by expression",
+			`SELECT id FROM foo ORDER BY lower(bogus)`,
+			errors.New("column `bogus` is not defined in table `foo`"),
+		},
 		{
 			"invalid column in group by",
 			`SELECT MAX(id), value FROM foo GROUP BY oops`,
 			errors.New("column `oops` is not defined in table `foo`"),
 		},
+		{
+			"invalid column in group by expression",
+			`SELECT id FROM foo GROUP BY lower(bogus)`,
+			errors.New("column `bogus` is not defined in table `foo`"),
+		},
+		{
+			"invalid column in distinct on",
+			`SELECT DISTINCT ON (oops) id FROM foo`,
+			errors.New("column `oops` is not defined in table `foo`"),
+		},
 		{
 			"invalid column in having",
 			`SELECT MAX(id), value FROM foo GROUP BY value HAVING MAX(uid) > 1`,
@@ -351,6 +596,48 @@ func TestInvalidSelect(t *testing.T) {
 			`SELECT wf() OVER w FROM foo WINDOW w AS (PARTITION BY value ORDER BY oops)`,
 			errors.New("column `oops` is not defined in table `foo`"),
 		},
+		{
+			"table name of aliased table",
+			`SELECT foo.id FROM foo f`,
+			errors.New("table `foo` not available for query"),
+		},
+		{
+			"invalid column in correlated subquery",
+			`SELECT id FROM foo f WHERE EXISTS (SELECT 1 FROM bar b WHERE b.id = f.oops)`,
+			errors.New("invalid WHERE clause: column `oops` is not defined in table `f`"),
+		},
+		{
+			"sibling subquery CTE",
+			`SELECT id FROM foo
+			WHERE id IN (WITH cte1 AS (SELECT id FROM bar) SELECT id FROM cte1)
+			AND id IN (SELECT id FROM cte1)`,
+			errors.New("invalid WHERE clause: invalid table name: cte1"),
+		},
+		{
+			"sibling subquery alias",
+			`SELECT s1.id FROM (SELECT id FROM foo) s1, (SELECT s1.id FROM bar) s2`,
+			errors.New("table `s1` not available for query"),
+		},
+		{
+			"invalid column of subquery",
+			`SELECT s.value FROM (SELECT id FROM foo) s`,
+			errors.New("column `value` is not defined in table `s`"),
+		},
+		{
+			"invalid column of CTE",
+			`WITH cte1 AS (SELECT id FROM foo WHERE value = 'a') SELECT value FROM cte1`,
+			errors.New("column `value` is not defined in table `cte1`"),
+		},
+		{
+			"invalid column in UNION",
+			`SELECT id FROM foo UNION SELECT bogus FROM bar`,
+			errors.New("column `bogus` is not defined in table `bar`"),
+		},
+		{
+			"table of other set operation arm",
+			`SELECT id FROM foo EXCEPT SELECT foo.id FROM bar`,
+			errors.New("table `foo` not available for query"),
+		},
 	}
 
 	for _, tcase := range testCases {
@@ -458,6 +745,71 @@ func TestSelect(t *testing.T) {
                          cte2 AS (SELECT value FROM foo)
 					SELECT c1.id, c2.value FROM cte1 c1, cte2 c2`,
 		},
+		{
+			"select correlated EXISTS",
+			`SELECT id FROM foo f WHERE EXISTS (SELECT 1 FROM bar b WHERE b.id = f.id AND value = 'a')`,
+		},
+		{
+			"select correlated scalar subquery",
+			`SELECT id, (SELECT count FROM bar WHERE bar.id = foo.id) FROM foo`,
+		},
+		{
+			"select subquery shadowing outer table",
+			`SELECT id FROM foo WHERE EXISTS (SELECT 1 FROM foo f2 WHERE f2.id = foo.id)`,
+		},
+		{
+			"select CTE in correlated subquery",
+			`WITH cte1 AS (SELECT id FROM bar)
+			SELECT id FROM foo WHERE EXISTS (SELECT 1 FROM cte1 WHERE cte1.id = foo.id)`,
+		},
+		{
+			"select star from CTE",
+			`WITH cte1 AS (SELECT * FROM baz) SELECT created_at, baz_count FROM cte1`,
+		},
+		{
+			"select CTE with column aliases",
+			`WITH cte1 (a, b) AS (SELECT id, value FROM foo) SELECT a, b FROM cte1`,
+		},
+		{
+			"select subquery with column aliases",
+			`SELECT s.a, s.value FROM (SELECT id, value FROM foo) AS s (a)`,
+		},
+		{
+			"select LATERAL after join",
+			`SELECT f.id, l.count
+			FROM foo f
+			JOIN bar b ON b.id = f.id
+			CROSS JOIN LATERAL (SELECT b.count WHERE f.value = 'a') l`,
+		},
+		{
+			"select order by and group by output name",
+			`SELECT lower(value) AS v, count(*) FROM foo GROUP BY v ORDER BY v`,
+		},
+		{
+			"select join using",
+			`SELECT id, s.value FROM foo JOIN bar USING (id) JOIN (SELECT id, value FROM foo) s USING (id, value)`,
+		},
+		{
+			"select from function",
+			`SELECT g.n FROM generate_series(1, 3) AS g(n)`,
+		},
+		{
+			"select set operations",
+			`WITH cte1 AS (SELECT id FROM bar)
+			SELECT id FROM foo UNION ALL SELECT count FROM bar INTERSECT SELECT id FROM cte1`,
+		},
+		{
+			"select enum comparison",
+			`SELECT id FROM qux WHERE status = 'pending' OR status <> ANY('{shipped}') OR status IS NULL`,
+		},
+		{
+			"select public schema table",
+			`SELECT public.foo.id, foo.value FROM public.foo`,
+		},
+		{
+			"select schema qualified table",
+			`SELECT u.password, auth.users.id FROM auth.users JOIN auth.users u ON u.id = users.id`,
+		},
 	}
 
 	for _, tcase := range testCases {
@@ -472,6 +824,57 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+func TestSearchPath(t *testing.T) {
+	query := `SELECT id, password FROM users`
+
+	_, err := vet.ValidateSqlQuery(mockCtx(), query)
+	assert.EqualError(t, err, "column `password` is not defined in table `users`")
+
+	ctx := mockCtx()
+	ctx.Schema.SearchPath = []string{"auth", "public"}
+	_, err = vet.ValidateSqlQuery(ctx, query)
+	assert.NoError(t, err)
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT id FROM foo`)
+	assert.NoError(t, err)
+}
+
+func TestFunctions(t *testing.T) {
+	ctx := mockCtx()
+	ctx.Schema.Functions = map[string][]schema.Function{
+		"slugify":        {{Name: "slugify", Schema: "public", MinArgs: 1, MaxArgs: 2}},
+		"auth.has_scope": {{Name: "has_scope", Schema: "auth", MinArgs: 2, MaxArgs: 2}},
+	}
+
+	_, err := vet.ValidateSqlQuery(ctx, `SELECT slugify(value), count(*), pg_catalog.upper(value), ext.anything(1, 2, 3)
+		FROM foo WHERE auth.has_scope(id, 'admin')`)
+	assert.NoError(t, err)
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT slugify() FROM foo`)
+	assert.EqualError(t, err, "function `slugify` expects 1 to 2 arguments, but received 0")
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT id FROM foo WHERE has_scope(id, 'admin')`)
+	assert.EqualError(t, err, "invalid WHERE clause: function `has_scope` does not exist")
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT pg_catalog.slugify(value) FROM foo`)
+	assert.EqualError(t, err, "function `pg_catalog.slugify` does not exist")
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT pg_notify('events', value), to_regtype('int4'), pg_catalog.age(now()) FROM foo`)
+	assert.NoError(t, err)
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT levenshtein(value, 'foo') FROM foo`)
+	assert.EqualError(t, err, "function `levenshtein` does not exist")
+
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT lower_text(value) FROM foo`)
+	assert.EqualError(t, err, "function `lower_text` does not exist")
+
+	ctx.Schema.Extensions = []string{"fuzzystrmatch"}
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT levenshtein(value, 'foo') FROM foo`)
+	assert.NoError(t, err)
+	_, err = vet.ValidateSqlQuery(ctx, `SELECT slugify() FROM foo`)
+	assert.EqualError(t, err, "function `slugify` expects 1 to 2 arguments, but received 0")
+}
+
 func TestUpdate(t *testing.T) {
 	testCases := []struct {
 		Name  string
@@ -481,6 +884,10 @@ func TestUpdate(t *testing.T) {
 			"update all to null",
 			`UPDATE foo SET value=NULL`,
 		},
+		{
+			"update from subquery",
+			`UPDATE foo SET id=b.count FROM (SELECT id, count FROM bar) b WHERE foo.id = b.id`,
+		},
 		{
 			"update id for all",
 			`UPDATE foo SET id=1`,
@@ -509,6 +916,30 @@ func TestUpdate(t *testing.T) {
 			"update alias with returning",
 			`UPDATE foo f SET id=1 RETURNING f.value`,
 		},
+		{
+			"update typed literals",
+			`UPDATE qux SET id=-1, active='off', uid=NULL, day='2024-01-31', amount=10`,
+		},
+		{
+			"update integer bounds",
+			`UPDATE foo SET id=-2147483648 WHERE id=2147483647`,
+		},
+		{
+			"update bigint bounds",
+			`UPDATE auth.users SET id=-9223372036854775808`,
+		},
+		{
+			"update bigint max with rounding",
+			`UPDATE auth.users SET id=9223372036854775807.4`,
+		},
+		{
+			"update enum column",
+			`UPDATE qux SET status='shipped' WHERE status IN ('pending', 'delivered')`,
+		},
+		{
+			"update generated column to default",
+			`UPDATE users SET name_upper=DEFAULT, nickname=NULL`,
+		},
 		{
 			"update CTE",
 			`WITH cte1 AS (SELECT id FROM foo)
@@ -544,6 +975,11 @@ func TestInvalidUpdate(t *testing.T) {
 			`UPDATE foononexist SET id=1`,
 			errors.New("invalid table name: foononexist"),
 		},
+		{
+			"invalid column of subquery in from",
+			`UPDATE foo SET id=b.oops FROM (SELECT id, count FROM bar) b WHERE foo.id = b.id`,
+			errors.New("column `oops` is not defined in table `b`"),
+		},
 		{
 			"read-only table",
 			`UPDATE baz SET created_at=1`,
@@ -559,6 +995,11 @@ func TestInvalidUpdate(t *testing.T) {
 			`UPDATE foo SET value='bar' WHERE date=NOW() OR 1=1`,
 			errors.New("column `date` is not defined in table `foo`"),
 		},
+		{
+			"invalid column in set expression",
+			`UPDATE foo SET value=lower(bogus_col)`,
+			errors.New("column `bogus_col` is not defined in table `foo`"),
+		},
 		{
 			"invalid table in from clause",
 			`UPDATE foo SET value=count FROM foononexist WHERE foononexist.id=1`,
@@ -574,6 +1015,56 @@ func TestInvalidUpdate(t *testing.T) {
 			`UPDATE foo SET id=1 RETURNING date`,
 			errors.New("column `date` is not defined in table `foo`"),
 		},
+		{
+			"string into integer column",
+			`UPDATE foo SET id='abc'`,
+			errors.New("invalid value 'abc' for column `id` of type int"),
+		},
+		{
+			"out of range for smallint",
+			`UPDATE qux SET active=true, id=-32769`,
+			errors.New("value -32769 is out of range for column `id` of type int2"),
+		},
+		{
+			"out of range for integer",
+			`UPDATE foo SET id=-2147483649`,
+			errors.New("value -2147483649 is out of range for column `id` of type int"),
+		},
+		{
+			"out of range for bigint",
+			`UPDATE auth.users SET id=9223372036854775807.5`,
+			errors.New("value 9223372036854775807.5 is out of range for column `id` of type int8"),
+		},
+		{
+			"malformed uuid",
+			`UPDATE qux SET uid='not-a-uuid' WHERE id=1`,
+			errors.New("invalid value 'not-a-uuid' for column `uid` of type uuid"),
+		},
+		{
+			"NULL into NOT NULL column",
+			`UPDATE users SET name=NULL`,
+			errors.New("null value in column `name` violates NOT NULL constraint"),
+		},
+		{
+			"generated column",
+			`UPDATE users SET name_upper='A'`,
+			errors.New("column `name_upper` can only be updated to DEFAULT"),
+		},
+		{
+			"boolean into timestamp column",
+			`UPDATE qux SET created_at=false`,
+			errors.New("invalid value false for column `created_at` of type timestamptz"),
+		},
+		{
+			"invalid enum label",
+			`UPDATE qux SET status='Shipped' WHERE id=1`,
+			errors.New("invalid value 'Shipped' for column `status` of type order_status"),
+		},
+		{
+			"invalid enum label in where",
+			`UPDATE qux SET status='shipped' WHERE status='pendin'`,
+			errors.New("invalid value 'pendin' for column `status` of type order_status"),
+		},
 	}
 
 	for _, tcase := range testCases {
@@ -610,6 +1101,10 @@ func TestDelete(t *testing.T) {
 			"delete using with aliases",
 			`DELETE FROM foo AS f USING bar b WHERE f.id = b.id`,
 		},
+		{
+			"delete using subquery",
+			`DELETE FROM foo USING (SELECT id FROM bar WHERE count > 1) b WHERE foo.id = b.id`,
+		},
 		{
 			"delete CTE",
 			`WITH cte1 AS (SELECT id FROM foo)
@@ -650,6 +1145,11 @@ func TestInvalidDelete(t *testing.T) {
 			`DELETE FROM baz WHERE created_at=1`,
 			errors.New("read-only table: baz"),
 		},
+		{
+			"invalid column of subquery in using",
+			`DELETE FROM foo USING (SELECT id FROM bar) b WHERE foo.id = b.count`,
+			errors.New("column `count` is not defined in table `b`"),
+		},
 		{
 			"invalid column",
 			`DELETE FROM foo WHERE date=NOW()`,
@@ -720,30 +1220,137 @@ func TestQueryParams(t *testing.T) {
 			"select",
 			"SELECT id FROM foo WHERE value=$1",
 			[]vet.QueryParam{
-				{1},
+				{1, "varchar"},
 			},
 		},
 		{
 			"update",
 			"UPDATE foo SET value=$1 WHERE id=$2",
 			[]vet.QueryParam{
-				{1},
-				{2},
+				{1, "varchar"},
+				{2, "int"},
 			},
 		},
 		{
 			"insert",
 			"INSERT INTO foo (id, value) VALUES ($1, $2)",
 			[]vet.QueryParam{
-				{1},
-				{2},
+				{1, "int"},
+				{2, "varchar"},
 			},
 		},
 		{
 			"delete",
 			"DELETE FROM foo WHERE id=$1",
 			[]vet.QueryParam{
-				{1},
+				{1, "int"},
+			},
+		},
+		{
+			"nested expressions",
+			"SELECT id FROM foo WHERE id=$2 AND id IN (SELECT id FROM bar WHERE count=$1) LIMIT $3",
+			[]vet.QueryParam{
+				{1, "int"},
+				{2, "int"},
+				{3, "int8"},
+			},
+		},
+		{
+			"gap",
+			"SELECT id FROM foo WHERE id=$1 OR id=$3",
+			[]vet.QueryParam{
+				{1, "int"},
+				{3, "int"},
+			},
+		},
+		{
+			"cast",
+			"SELECT id FROM foo WHERE id=$1::text AND value=ANY($2::text[])",
+			[]vet.QueryParam{
+				{1, "text"},
+				{2, "text[]"},
+			},
+		},
+		{
+			"insert on conflict",
+			"INSERT INTO foo (id) VALUES ($1) ON CONFLICT (id) DO UPDATE SET value=$2",
+			[]vet.QueryParam{
+				{1, "int"},
+				{2, "varchar"},
+			},
+		},
+		{
+			"update from subquery",
+			"UPDATE foo SET value=$1 FROM (SELECT id FROM bar) b WHERE b.id = foo.id AND foo.id = $2",
+			[]vet.QueryParam{
+				{1, "varchar"},
+				{2, "int"},
+			},
+		},
+		{
+			"shadowed alias",
+			"SELECT id FROM foo f WHERE value = $1 AND EXISTS (SELECT 1 FROM bar f WHERE f.count = $2)",
+			[]vet.QueryParam{
+				{1, "varchar"},
+				{2, "int"},
+			},
+		},
+		{
+			"union",
+			"SELECT id FROM foo WHERE value = $1 UNION SELECT id FROM bar WHERE count = $2",
+			[]vet.QueryParam{
+				{1, "varchar"},
+				{2, "int"},
+			},
+		},
+		{
+			"union limit",
+			"SELECT id FROM foo UNION SELECT id FROM bar ORDER BY id LIMIT $1",
+			[]vet.QueryParam{
+				{1, "int8"},
+			},
+		},
+		{
+			"cte",
+			"WITH x AS (SELECT id FROM foo WHERE id = $1) SELECT id FROM x",
+			[]vet.QueryParam{
+				{1, "int"},
+			},
+		},
+		{
+			"function in from",
+			"SELECT g.n FROM generate_series(1, $1) AS g(n)",
+			[]vet.QueryParam{
+				{1, ""},
+			},
+		},
+		{
+			"insert select",
+			"INSERT INTO foo (id, value) SELECT $1, lower(value) FROM foo ORDER BY id LIMIT $2",
+			[]vet.QueryParam{
+				{1, ""},
+				{2, "int8"},
+			},
+		},
+		{
+			"insert on conflict where",
+			"INSERT INTO foo (id) VALUES (1) ON CONFLICT (id) DO UPDATE SET value = excluded.value WHERE foo.id = $1",
+			[]vet.QueryParam{
+				{1, "int"},
+			},
+		},
+		{
+			"returning",
+			"UPDATE foo SET value = 'a' RETURNING lower(value), $1",
+			[]vet.QueryParam{
+				{1, ""},
+			},
+		},
+		{
+			"unknown",
+			"SELECT id FROM foo WHERE $1 = 1",
+			[]vet.QueryParam{
+				{1, ""},
 			},
 		},
 	}
@@ -759,3 +1366,82 @@ func TestQueryParams(t *testing.T) {
 		})
 	}
 }
+
+// benchQueries is a query corpus covering statements and clauses validated
+// against mockDbSchema
+var benchQueries = []string{
+	"SELECT id, value FROM foo WHERE id = $1",
+	"SELECT f.id, b.count FROM foo f JOIN bar b ON b.id = f.id WHERE f.value = $1 ORDER BY b.count LIMIT $2",
+	"SELECT id, count(*) FROM bar GROUP BY id HAVING count(*) > $1",
+	"SELECT id FROM foo WHERE id IN (SELECT id FROM bar WHERE count > $1)",
+	"SELECT id FROM foo WHERE EXISTS (SELECT 1 FROM bar WHERE bar.id = $1)",
+	"WITH recent AS (SELECT id, value FROM foo WHERE id > $1) SELECT id FROM recent",
+	"SELECT id, CASE WHEN count > $1 THEN 'many' ELSE 'few' END FROM bar",
+	"SELECT sum(count) OVER (PARTITION BY id ORDER BY count) FROM bar",
+	"INSERT INTO foo (id, value) VALUES ($1, $2)",
+	"INSERT INTO foo (id, value) VALUES (1, 'a'), (2, 'b') RETURNING id",
+	"INSERT INTO bar (id, count) SELECT id, $1 FROM foo WHERE value = $2",
+	"UPDATE bar SET count = count + $1 WHERE id = $2",
+	"UPDATE foo SET value = $1 FROM bar WHERE foo.id = bar.id AND bar.count > $2",
+	"DELETE FROM foo WHERE id = $1 RETURNING value",
+}
+
+// warmUpParser makes sure the parser is initialized before a benchmark
+// starts, initialization takes much longer than parsing a query
+func warmUpParser(b *testing.B) {
+	if _, err := pg_wasm.Parse("SELECT 1"); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+}
+
+func BenchmarkValidateSqlQuery(b *testing.B) {
+	ctx := mockCtx()
+	warmUpParser(b)
+	for i := 0; i < b.N; i++ {
+		for _, query := range benchQueries {
+			if _, err := vet.ValidateSqlQuery(ctx, query); err != nil {
+				b.Fatalf("%s: %s", query, err)
+			}
+		}
+	}
+}
+
+// BenchmarkParse compares parsing the corpus into the AST the validator
+// walks, the same AST decoded from protobuf output of the parser and the
+// JSON parse tree decoded into generic maps.
+func BenchmarkParse(b *testing.B) {
+	warmUpParser(b)
+	b.Run("ast", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, query := range benchQueries {
+				if _, err := parseutil.Parse(query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("protobuf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, query := range benchQueries {
+				if _, err := pg_wasm.Parse(query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, query := range benchQueries {
+				tree, err := pg_wasm.ParseToJSON(query)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var root map[string]any
+				if err := json.Unmarshal([]byte(tree), &root); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package vet
+
+import (
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/houqp/sqlvet/pkg/schema"
+)
+
+// LoadSchema loads DB schema merged from schemaPaths, view columns are
+// derived with the same scope resolution used to validate queries.
+func LoadSchema(schemaPaths ...string) (*schema.Db, error) {
+	return loadSchema(nil, schemaPaths...)
+}
+
+// loadSchema is LoadSchema skipping files in exclude found in schema
+// directories and glob matches
+func loadSchema(exclude []string, schemaPaths ...string) (*schema.Db, error) {
+	db := &schema.Db{
+		Tables:      map[string]schema.Table{},
+		ViewColumns: viewColumns,
+		Exclude:     exclude,
+	}
+	if err := db.Load(schemaPaths...); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// viewColumns derives columns of a view query from tables defined before
+// it: `*` and `t.*` are expanded and columns are resolved through joins,
+// subqueries, CTEs and set operations.
+func viewColumns(tables map[string]schema.Table, searchPath []string, query *pg_query.Node) ([]string, bool) {
+	if query == nil {
+		return nil, false
+	}
+	ctx := NewContext(tables)
+	ctx.Schema.SearchPath = searchPath
+	return stmtColumns(ctx, query, map[string][]string{})
+}
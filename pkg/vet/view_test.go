@@ -0,0 +1,52 @@
+package vet_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/houqp/sqlvet/pkg/vet"
+)
+
+func TestLoadSchemaViews(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "schema.sql")
+	err := os.WriteFile(schemaPath, []byte(`
+CREATE TABLE users (id integer, name text);
+CREATE TABLE posts (id integer, user_id integer, title text);
+CREATE VIEW user_posts AS
+	SELECT u.*, p.title, count(*) OVER ()
+	FROM users u JOIN posts p ON p.user_id = u.id;
+CREATE VIEW titles (post_id) AS
+	WITH t AS (SELECT id, title FROM posts)
+	SELECT * FROM t UNION SELECT id, name FROM users;
+CREATE VIEW merged AS SELECT * FROM users JOIN posts USING (id);
+CREATE VIEW unresolved AS SELECT *, missing.id FROM missing;
+CREATE MATERIALIZED VIEW post_stats AS
+	SELECT p.*, u.name FROM posts p JOIN users u ON u.id = p.user_id;
+CREATE SCHEMA reporting
+	CREATE VIEW user_titles AS SELECT u.*, p.title FROM users u JOIN posts p ON p.user_id = u.id;
+ALTER TABLE users ADD COLUMN email text;
+CREATE TABLE people (name text, id integer, email text);
+CREATE VIEW aliased (a) AS SELECT * FROM people;
+`), 0644)
+	require.NoError(t, err)
+
+	db, err := vet.LoadSchema(schemaPath)
+	require.NoError(t, err)
+
+	columns := func(view string) []string {
+		return db.Tables[view].ColumnNames()
+	}
+	require.Equal(t, []string{"id", "name", "title", "count"}, columns("user_posts"))
+	require.Equal(t, []string{"post_id", "title"}, columns("titles"))
+	require.Equal(t, []string{"id", "name", "user_id", "title"}, columns("merged"))
+	require.Equal(t, []string{"id"}, columns("unresolved"))
+	require.Equal(t, []string{"id", "user_id", "title", "name"}, columns("post_stats"))
+	require.Equal(t, []string{"id", "name", "title"}, columns("reporting.user_titles"))
+	// column aliases are applied by position
+	require.Equal(t, []string{"a", "id", "email"}, columns("aliased"))
+	require.True(t, db.Tables["user_posts"].ReadOnly)
+	require.True(t, db.Tables["post_stats"].ReadOnly)
+}
@@ -0,0 +1,72 @@
+package vet
+
+import (
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// walkNodes calls visit for n and all nodes nested in it, depth first in
+// field order. Children of nodes visit returns false for are skipped.
+func walkNodes(n *pg_query.Node, visit func(*pg_query.Node) bool) {
+	if n.GetNode() == nil || !visit(n) {
+		return
+	}
+	walkChildren(n, visit)
+}
+
+// walkChildren calls walkNodes for nodes nested in n, including the ones
+// kept in fields that are not nodes themselves, e.g. TypeName of TypeCast.
+func walkChildren(n *pg_query.Node, visit func(*pg_query.Node) bool) {
+	if _, m := nodeMessage(n); m != nil {
+		walkMessage(m, visit)
+	}
+}
+
+func walkMessage(m protoreflect.Message, visit func(*pg_query.Node) bool) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || !m.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				walkValue(list.Get(j).Message(), visit)
+			}
+		} else {
+			walkValue(m.Get(fd).Message(), visit)
+		}
+	}
+}
+
+func walkValue(m protoreflect.Message, visit func(*pg_query.Node) bool) {
+	if n, ok := m.Interface().(*pg_query.Node); ok {
+		walkNodes(n, visit)
+		return
+	}
+	walkMessage(m, visit)
+}
+
+// nodeMessage returns the message n wraps, e.g. SelectStmt, along with its
+// field in Node. Both are nil for empty nodes.
+func nodeMessage(n *pg_query.Node) (protoreflect.FieldDescriptor, protoreflect.Message) {
+	if n.GetNode() == nil {
+		return nil, nil
+	}
+	m := n.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("node"))
+	if fd == nil {
+		return nil, nil
+	}
+	return fd, m.Get(fd).Message()
+}
+
+// nodeKind returns type name of the node, e.g. SelectStmt
+func nodeKind(n *pg_query.Node) string {
+	fd, _ := nodeMessage(n)
+	if fd == nil {
+		return ""
+	}
+	return string(fd.Message().Name())
+}
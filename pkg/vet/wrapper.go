@@ -0,0 +1,195 @@
+package vet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// queryFuncFact is exported for functions that forward one of their string
+// parameters as query string into a known query function. Call sites of these
+// wrappers, including the ones from other packages, are then validated the
+// same way as direct calls to query functions.
+type queryFuncFact struct {
+	// zero indexed, receiver is not taken into account
+	QueryArgPos int
+	// query uses sqlx style named parameters
+	Named bool
+	// query parameters are taken as variadic argument right after the query
+	VariadicArgs bool
+	// Database bound to the wrapped query function through its matcher
+	Database string
+	// Package the wrapper calling into query function is defined in, stacked
+	// wrappers keep package of the innermost one
+	Package string
+}
+
+func (*queryFuncFact) AFact() {}
+
+func (f *queryFuncFact) String() string {
+	s := fmt.Sprintf("queryFunc(%d", f.QueryArgPos)
+	if f.Named {
+		s += ", named"
+	}
+	if f.VariadicArgs {
+		s += ", args"
+	}
+	if f.Database != "" {
+		s += ", db=" + f.Database
+	}
+	if f.Package != "" {
+		s += ", pkg=" + f.Package
+	}
+	return s + ")"
+}
+
+// lookupQueryFunc returns query argument info if fn is a configured query
+// function or a wrapper of one.
+func lookupQueryFunc(pass *analysis.Pass, state *analyzerState, fn *types.Func) (*queryFuncFact, bool) {
+	if m, pos, ok := state.matchQueryFunc(fn); ok {
+		sig := fn.Type().(*types.Signature)
+		return &queryFuncFact{
+			QueryArgPos:  pos,
+			Named:        isNamedQueryFunc(fn.Name()),
+			VariadicArgs: sig.Variadic() && pos == sig.Params().Len()-2,
+			Database:     m.Database,
+		}, true
+	}
+	var fact queryFuncFact
+	if pass.ImportObjectFact(fn, &fact) {
+		return &fact, true
+	}
+	return nil, false
+}
+
+// exportQueryFuncFacts finds query function wrappers declared in the current
+// package and exports a queryFuncFact for each of them. Wrappers can be
+// stacked, so we keep scanning until no new wrapper shows up.
+func exportQueryFuncFacts(pass *analysis.Pass, state *analyzerState) {
+	decls := []*ast.FuncDecl{}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fdecl, ok := decl.(*ast.FuncDecl); ok && fdecl.Body != nil {
+				decls = append(decls, fdecl)
+			}
+		}
+	}
+
+	found := true
+	for found {
+		found = false
+		for _, fdecl := range decls {
+			fn, ok := pass.TypesInfo.Defs[fdecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			if _, ok := lookupQueryFunc(pass, state, fn); ok {
+				continue
+			}
+			if fact, ok := findForwardedQueryParam(pass, state, fdecl, fn); ok {
+				pass.ExportObjectFact(fn, fact)
+				found = true
+			}
+		}
+	}
+}
+
+// findForwardedQueryParam looks for a parameter of fn that is passed
+// unmodified as query argument to a known query function. Query parameters
+// are counted at call sites of fn only if its variadic parameter follows the
+// query and is forwarded as `args...`.
+func findForwardedQueryParam(pass *analysis.Pass, state *analyzerState, fdecl *ast.FuncDecl, fn *types.Func) (*queryFuncFact, bool) {
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params()
+	var variadicParam *types.Var
+	if sig.Variadic() {
+		variadicParam = params.At(params.Len() - 1)
+	}
+	paramPos := map[*types.Var]int{}
+	for i := 0; i < params.Len(); i++ {
+		if sig.Variadic() && i == params.Len()-1 {
+			continue
+		}
+		if basic, ok := params.At(i).Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+			continue
+		}
+		paramPos[params.At(i)] = i
+	}
+	if len(paramPos) == 0 {
+		return nil, false
+	}
+
+	modified := map[*types.Var]bool{}
+	forwarded := -1
+	var fact queryFuncFact
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// closures are not traced
+			return false
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if v := identVar(pass, lhs); v != nil {
+					modified[v] = true
+				}
+			}
+		case *ast.UnaryExpr:
+			// parameter could be modified through pointer
+			if v := identVar(pass, node.X); v != nil && node.Op == token.AND {
+				modified[v] = true
+			}
+		case *ast.CallExpr:
+			if forwarded >= 0 {
+				return true
+			}
+			callee := resolveCallee(pass, node)
+			if callee == nil || callee == fn {
+				return true
+			}
+			qf, ok := lookupQueryFunc(pass, state, callee)
+			if !ok || qf.QueryArgPos >= len(node.Args) {
+				return true
+			}
+			v := identVar(pass, node.Args[qf.QueryArgPos])
+			pos, ok := paramPos[v]
+			if !ok {
+				return true
+			}
+			forwarded = pos
+			fact = queryFuncFact{
+				QueryArgPos: pos,
+				Named:       qf.Named,
+				Database:    qf.Database,
+				Package:     qf.Package,
+			}
+			if fact.Package == "" {
+				fact.Package = pass.Pkg.Path()
+			}
+			if qf.VariadicArgs && variadicParam != nil && pos == params.Len()-2 &&
+				node.Ellipsis.IsValid() && len(node.Args) == qf.QueryArgPos+2 {
+				fact.VariadicArgs = identVar(pass, node.Args[qf.QueryArgPos+1]) == variadicParam
+			}
+		}
+		return true
+	})
+
+	if forwarded < 0 || modified[params.At(forwarded)] {
+		return nil, false
+	}
+	if variadicParam != nil && modified[variadicParam] {
+		fact.VariadicArgs = false
+	}
+	return &fact, true
+}
+
+func identVar(pass *analysis.Pass, e ast.Expr) *types.Var {
+	ident, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, _ := pass.TypesInfo.Uses[ident].(*types.Var)
+	return v
+}